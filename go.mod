@@ -7,4 +7,6 @@ require (
 	github.com/cli/cli v1.14.1-0.20210823190025-e2973453b5cd
 	github.com/cli/safeexec v1.0.0
 	github.com/spf13/cobra v1.2.1
+	golang.org/x/term v0.0.0-20210503060354-a79de5458b56
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 )
@@ -0,0 +1,325 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/heaths/gh-label/internal/github"
+)
+
+// Reasons two labels may be redundant.
+const (
+	// CaseReason means names differ only in case, like "bug" and "Bug".
+	CaseReason = "case"
+
+	// PunctuationReason means names differ only in punctuation or whitespace, like "good first issue" and "good-first-issue".
+	PunctuationReason = "punctuation"
+
+	// PluralReason means one name is the plural of the other, like "bug" and "bugs".
+	PluralReason = "plural"
+
+	// ScopeReason means names differ only in whether they have a scope, like "bug" and "type: bug".
+	ScopeReason = "scope"
+
+	// SpellingReason means names are within a small edit distance, like "enhancement" and "enhancment".
+	SpellingReason = "spelling"
+
+	// DescriptionReason means descriptions are the same or nearly the same.
+	DescriptionReason = "description"
+
+	// CoOccurrenceReason means labels are usually applied together.
+	CoOccurrenceReason = "co-occurrence"
+)
+
+// Matrix counts how many issues and pull requests carry each pair of labels.
+type Matrix struct {
+	// Labels are the names of labels in the same order as Counts.
+	Labels []string `json:"labels"`
+
+	// Counts[i][j] is the number of issues and pull requests carrying both Labels[i] and Labels[j].
+	// Counts[i][i] is the number carrying Labels[i].
+	Counts [][]int `json:"counts"`
+}
+
+// CoOccurrenceMatrix returns a Matrix for labels across issues and pull requests. Labels are matched by name without regard to case.
+func CoOccurrenceMatrix(labels github.Labels, issues []github.Issue) Matrix {
+	m := Matrix{
+		Labels: make([]string, len(labels)),
+		Counts: make([][]int, len(labels)),
+	}
+
+	index := make(map[string]int, len(labels))
+	for i, label := range labels {
+		m.Labels[i] = label.Name
+		m.Counts[i] = make([]int, len(labels))
+		index[strings.ToLower(label.Name)] = i
+	}
+
+	for _, issue := range issues {
+		var indexes []int
+		for _, name := range distinctNames(issue.Labels) {
+			if i, ok := index[strings.ToLower(name)]; ok {
+				indexes = append(indexes, i)
+			}
+		}
+
+		for _, i := range indexes {
+			for _, j := range indexes {
+				m.Counts[i][j]++
+			}
+		}
+	}
+
+	return m
+}
+
+// Jaccard returns the number of issues and pull requests carrying both Labels[i] and Labels[j]
+// divided by the number carrying either, or 0 if neither label is used.
+func (m Matrix) Jaccard(i, j int) float64 {
+	union := m.Counts[i][i] + m.Counts[j][j] - m.Counts[i][j]
+	if union == 0 {
+		return 0
+	}
+	return float64(m.Counts[i][j]) / float64(union)
+}
+
+// Options configures how Redundancies are found.
+type Options struct {
+	// MinJaccard is the minimum Jaccard similarity for labels to be reported as usually applied together.
+	MinJaccard float64
+
+	// MinCount is the minimum number of issues and pull requests both labels must be applied to
+	// before their Jaccard similarity is considered.
+	MinCount int
+
+	// ScopeSeparator separates a scope from the rest of a label name. The default is ":".
+	ScopeSeparator string
+}
+
+// DefaultOptions returns the default Options.
+func DefaultOptions() Options {
+	return Options{
+		MinJaccard:     0.8,
+		MinCount:       3,
+		ScopeSeparator: ":",
+	}
+}
+
+// Redundancy is a pair of labels that may be redundant.
+type Redundancy struct {
+	Labels [2]string `json:"labels"`
+
+	// Reasons are why the labels may be redundant, like CaseReason or CoOccurrenceReason.
+	Reasons []string `json:"reasons"`
+
+	// Together is the number of issues and pull requests carrying both labels.
+	Together int `json:"together"`
+
+	// Jaccard is the Jaccard similarity of the labels.
+	Jaccard float64 `json:"jaccard"`
+
+	// Into is the label to keep if merged, which is the label applied to more issues and pull requests.
+	Into string `json:"into"`
+}
+
+// From returns the label to merge into the other.
+func (r Redundancy) From() string {
+	if r.Into == r.Labels[0] {
+		return r.Labels[1]
+	}
+	return r.Labels[0]
+}
+
+// Redundancies returns pairs of labels with near-duplicate names or descriptions, or that are usually applied together,
+// ordered by Jaccard similarity and then by the order of labels.
+func Redundancies(labels github.Labels, m Matrix, opts Options) []Redundancy {
+	if opts.ScopeSeparator == "" {
+		opts.ScopeSeparator = ":"
+	}
+
+	var redundancies []Redundancy
+	for i := 0; i < len(labels); i++ {
+		for j := i + 1; j < len(labels); j++ {
+			a, b := labels[i], labels[j]
+
+			reasons := nameReasons(a.Name, b.Name, opts.ScopeSeparator)
+			if similarDescriptions(a.Description, b.Description) {
+				reasons = append(reasons, DescriptionReason)
+			}
+
+			jaccard := m.Jaccard(i, j)
+			if m.Counts[i][j] >= opts.MinCount && jaccard >= opts.MinJaccard {
+				reasons = append(reasons, CoOccurrenceReason)
+			}
+
+			if len(reasons) == 0 {
+				continue
+			}
+
+			into := a.Name
+			if m.Counts[j][j] > m.Counts[i][i] {
+				into = b.Name
+			}
+
+			redundancies = append(redundancies, Redundancy{
+				Labels:   [2]string{a.Name, b.Name},
+				Reasons:  reasons,
+				Together: m.Counts[i][j],
+				Jaccard:  math.Round(jaccard*100) / 100,
+				Into:     into,
+			})
+		}
+	}
+
+	sort.SliceStable(redundancies, func(i, j int) bool {
+		return redundancies[i].Jaccard > redundancies[j].Jaccard
+	})
+
+	return redundancies
+}
+
+// nameReasons returns the first reason names are near-duplicates, if any.
+func nameReasons(a, b, separator string) []string {
+	if a == b {
+		return nil
+	}
+
+	if strings.EqualFold(a, b) {
+		return []string{CaseReason}
+	}
+
+	na, nb := normalize(a), normalize(b)
+	if na == "" || nb == "" {
+		return nil
+	}
+
+	if na == nb {
+		return []string{PunctuationReason}
+	}
+
+	if singular(na) == singular(nb) {
+		return []string{PluralReason}
+	}
+
+	sa, ba := splitScope(a, separator)
+	sb, bb := splitScope(b, separator)
+	if (sa == "") != (sb == "") && ba != "" && ba == bb {
+		return []string{ScopeReason}
+	}
+
+	if misspelled(na, nb) {
+		return []string{SpellingReason}
+	}
+
+	return nil
+}
+
+// normalize returns only the lowercase letters and digits of s.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+func singular(s string) string {
+	if len(s) > 3 && strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss") {
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+// splitScope returns the scope preceding separator, if any, and the normalized name following it.
+func splitScope(name, separator string) (string, string) {
+	if i := strings.Index(name, separator); i >= 0 {
+		return normalize(name[:i]), singular(normalize(name[i+len(separator):]))
+	}
+	return "", singular(normalize(name))
+}
+
+// misspelled returns true if normalized names are within an edit distance that depends on their length.
+// Names that differ in digits, like "p1" and "p2", are never misspelled.
+func misspelled(a, b string) bool {
+	if digits(a) != digits(b) {
+		return false
+	}
+
+	n := len([]rune(a))
+	if m := len([]rune(b)); m < n {
+		n = m
+	}
+
+	switch d := distance(a, b); {
+	case n >= 10:
+		return d <= 2
+	case n >= 5:
+		return d <= 1
+	default:
+		return false
+	}
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// similarDescriptions returns true if non-empty descriptions are the same without regard to case, punctuation, or whitespace,
+// or differ by no more than a tenth of their length.
+func similarDescriptions(a, b string) bool {
+	na, nb := normalize(a), normalize(b)
+	if na == "" || nb == "" {
+		return false
+	}
+
+	n := len([]rune(na))
+	if m := len([]rune(nb)); m > n {
+		n = m
+	}
+
+	return distance(na, nb)*10 <= n
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minimum(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minimum(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func TestCoOccurrenceMatrix(t *testing.T) {
+	labels := github.Labels{{Name: "bug"}, {Name: "p1"}, {Name: "docs"}}
+	issues := []github.Issue{
+		{Labels: github.Labels{{Name: "bug"}, {Name: "P1"}}},
+		{Labels: github.Labels{{Name: "bug"}, {Name: "unknown"}}},
+		{Labels: github.Labels{{Name: "docs"}, {Name: "p1"}, {Name: "bug"}}},
+	}
+
+	want := Matrix{
+		Labels: []string{"bug", "p1", "docs"},
+		Counts: [][]int{
+			{3, 2, 1},
+			{2, 2, 1},
+			{1, 1, 1},
+		},
+	}
+
+	m := CoOccurrenceMatrix(labels, issues)
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("CoOccurrenceMatrix() = %v, expected %v", m, want)
+	}
+
+	if got := m.Jaccard(0, 1); got != 2.0/3.0 {
+		t.Errorf("Jaccard() = %v, expected %v", got, 2.0/3.0)
+	}
+}
+
+func TestRedundancies(t *testing.T) {
+	labels := github.Labels{
+		{Name: "bug", Description: "Something isn't working"},
+		{Name: "Bug"},
+		{Name: "type: bug"},
+		{Name: "good first issue"},
+		{Name: "good-first-issue"},
+		{Name: "enhancement"},
+		{Name: "enhancment"},
+		{Name: "doc"},
+		{Name: "docs"},
+		{Name: "p1"},
+		{Name: "p2"},
+		{Name: "priority: high"},
+		{Name: "severity: high"},
+		{Name: "defect", Description: "something is not working."},
+		{Name: "needs-triage"},
+		{Name: "new"},
+	}
+
+	issues := []github.Issue{
+		{Labels: github.Labels{{Name: "needs-triage"}, {Name: "new"}}},
+		{Labels: github.Labels{{Name: "needs-triage"}, {Name: "new"}}},
+		{Labels: github.Labels{{Name: "needs-triage"}, {Name: "new"}}},
+		{Labels: github.Labels{{Name: "needs-triage"}, {Name: "new"}, {Name: "p1"}}},
+		{Labels: github.Labels{{Name: "new"}, {Name: "Bug"}}},
+	}
+
+	want := []Redundancy{
+		{Labels: [2]string{"needs-triage", "new"}, Reasons: []string{CoOccurrenceReason}, Together: 4, Jaccard: 0.8, Into: "new"},
+		{Labels: [2]string{"bug", "Bug"}, Reasons: []string{CaseReason}, Into: "Bug"},
+		{Labels: [2]string{"bug", "type: bug"}, Reasons: []string{ScopeReason}, Into: "bug"},
+		{Labels: [2]string{"bug", "defect"}, Reasons: []string{DescriptionReason}, Into: "bug"},
+		{Labels: [2]string{"Bug", "type: bug"}, Reasons: []string{ScopeReason}, Into: "Bug"},
+		{Labels: [2]string{"good first issue", "good-first-issue"}, Reasons: []string{PunctuationReason}, Into: "good first issue"},
+		{Labels: [2]string{"enhancement", "enhancment"}, Reasons: []string{SpellingReason}, Into: "enhancement"},
+		{Labels: [2]string{"doc", "docs"}, Reasons: []string{PluralReason}, Into: "doc"},
+	}
+
+	m := CoOccurrenceMatrix(labels, issues)
+	got := Redundancies(labels, m, DefaultOptions())
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Redundancies() = %+v, expected %+v", got, want)
+	}
+}
+
+func TestRedundancy_From(t *testing.T) {
+	r := Redundancy{Labels: [2]string{"bug", "Bug"}, Into: "Bug"}
+	if got := r.From(); got != "bug" {
+		t.Errorf("From() = %q, expected %q", got, "bug")
+	}
+}
+
+func Test_distance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"bug", "", 3},
+		{"kitten", "sitting", 3},
+		{"enhancement", "enhancment", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got := distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("distance() = %d, expected %d", got, tt.want)
+			}
+		})
+	}
+}
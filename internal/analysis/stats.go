@@ -0,0 +1,175 @@
+// Package analysis computes how labels are used across issues and pull requests.
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-label/internal/github"
+)
+
+// Stats describes how a label is used.
+type Stats struct {
+	Label github.Label
+
+	OpenIssues         int
+	ClosedIssues       int
+	OpenPullRequests   int
+	ClosedPullRequests int
+
+	// FirstApplied and LastApplied are zero if the label was never applied in the events.
+	FirstApplied time.Time
+	LastApplied  time.Time
+
+	// MedianTimeToClose is the median time to close issues, excluding pull requests, that carry the label.
+	// It is only valid if ClosedIssues is greater than 0.
+	MedianTimeToClose time.Duration
+
+	// CoOccurrences are other labels on the same issues and pull requests, most frequent first.
+	CoOccurrences CoOccurrences
+}
+
+// CoOccurrence is the number of issues and pull requests on which another label was also applied.
+type CoOccurrence struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// CoOccurrences are other labels applied with a label.
+type CoOccurrences []CoOccurrence
+
+// String formats co-occurrences like "bug (3); needs-triage (1)".
+func (c CoOccurrences) String() string {
+	s := make([]string, len(c))
+	for i, o := range c {
+		s[i] = fmt.Sprintf("%s (%d)", o.Label, o.Count)
+	}
+	return strings.Join(s, "; ")
+}
+
+// ComputeStats returns Stats for each label in the same order. Labels are matched by name without regard to case.
+func ComputeStats(labels github.Labels, issues []github.Issue, events []github.LabelEvent) []Stats {
+	stats := make([]Stats, len(labels))
+	index := make(map[string]int, len(labels))
+	for i, label := range labels {
+		stats[i].Label = label
+		index[strings.ToLower(label.Name)] = i
+	}
+
+	durations := make([][]time.Duration, len(labels))
+	coOccurrences := make([]map[string]int, len(labels))
+
+	for _, issue := range issues {
+		names := distinctNames(issue.Labels)
+		for _, name := range names {
+			i, ok := index[strings.ToLower(name)]
+			if !ok {
+				continue
+			}
+
+			s := &stats[i]
+			closed := issue.State == "closed"
+			switch {
+			case issue.PullRequest && closed:
+				s.ClosedPullRequests++
+			case issue.PullRequest:
+				s.OpenPullRequests++
+			case closed:
+				s.ClosedIssues++
+				if issue.ClosedAt != nil {
+					durations[i] = append(durations[i], issue.ClosedAt.Sub(issue.CreatedAt))
+				}
+			default:
+				s.OpenIssues++
+			}
+
+			for _, other := range names {
+				if other == name {
+					continue
+				}
+				if coOccurrences[i] == nil {
+					coOccurrences[i] = make(map[string]int)
+				}
+				coOccurrences[i][other]++
+			}
+		}
+	}
+
+	for _, event := range events {
+		if event.Event != "labeled" {
+			continue
+		}
+
+		i, ok := index[strings.ToLower(event.Label.Name)]
+		if !ok {
+			continue
+		}
+
+		s := &stats[i]
+		if s.FirstApplied.IsZero() || event.CreatedAt.Before(s.FirstApplied) {
+			s.FirstApplied = event.CreatedAt
+		}
+		if event.CreatedAt.After(s.LastApplied) {
+			s.LastApplied = event.CreatedAt
+		}
+	}
+
+	for i := range stats {
+		stats[i].MedianTimeToClose = median(durations[i])
+		stats[i].CoOccurrences = sortCoOccurrences(coOccurrences[i])
+	}
+
+	return stats
+}
+
+// distinctNames returns label names without duplicates that differ only in case.
+func distinctNames(labels github.Labels) []string {
+	seen := make(map[string]bool, len(labels))
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		key := strings.ToLower(label.Name)
+		if !seen[key] {
+			seen[key] = true
+			names = append(names, label.Name)
+		}
+	}
+	return names
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	n := len(durations)
+	if n%2 == 1 {
+		return durations[n/2]
+	}
+	return (durations[n/2-1] + durations[n/2]) / 2
+}
+
+func sortCoOccurrences(counts map[string]int) CoOccurrences {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	c := make(CoOccurrences, 0, len(counts))
+	for label, count := range counts {
+		c = append(c, CoOccurrence{label, count})
+	}
+
+	sort.Slice(c, func(i, j int) bool {
+		if c[i].Count != c[j].Count {
+			return c[i].Count > c[j].Count
+		}
+		return strings.ToLower(c[i].Label) < strings.ToLower(c[j].Label)
+	})
+
+	return c
+}
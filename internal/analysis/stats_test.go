@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func date(day int) time.Time {
+	return time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+}
+
+func closed(day int) *time.Time {
+	t := date(day)
+	return &t
+}
+
+func TestComputeStats(t *testing.T) {
+	labels := github.Labels{
+		{Name: "bug", Color: "d73a4a"},
+		{Name: "feature", Color: "a2eeef"},
+		{Name: "wontfix", Color: "ffffff"},
+	}
+
+	issues := []github.Issue{
+		{Number: 1, State: "closed", CreatedAt: date(1), ClosedAt: closed(2), Labels: github.Labels{{Name: "bug"}, {Name: "p1"}}},
+		{Number: 2, State: "closed", CreatedAt: date(1), ClosedAt: closed(5), Labels: github.Labels{{Name: "Bug"}}},
+		{Number: 3, State: "closed", CreatedAt: date(1), ClosedAt: closed(11), Labels: github.Labels{{Name: "bug"}, {Name: "p1"}}},
+		{Number: 4, State: "open", CreatedAt: date(1), Labels: github.Labels{{Name: "bug"}, {Name: "feature"}}},
+		{Number: 5, State: "open", PullRequest: true, CreatedAt: date(1), Labels: github.Labels{{Name: "feature"}}},
+		{Number: 6, State: "closed", PullRequest: true, CreatedAt: date(1), ClosedAt: closed(20), Labels: github.Labels{{Name: "bug"}}},
+	}
+
+	events := []github.LabelEvent{
+		{Event: "labeled", CreatedAt: date(3), Label: github.Label{Name: "bug"}},
+		{Event: "unlabeled", CreatedAt: date(9), Label: github.Label{Name: "bug"}},
+		{Event: "labeled", CreatedAt: date(1), Label: github.Label{Name: "BUG"}},
+		{Event: "labeled", CreatedAt: date(4), Label: github.Label{Name: "feature"}},
+	}
+
+	want := []Stats{
+		{
+			Label:              labels[0],
+			OpenIssues:         1,
+			ClosedIssues:       3,
+			ClosedPullRequests: 1,
+			FirstApplied:       date(1),
+			LastApplied:        date(3),
+			MedianTimeToClose:  4 * 24 * time.Hour,
+			CoOccurrences:      CoOccurrences{{"p1", 2}, {"feature", 1}},
+		},
+		{
+			Label:            labels[1],
+			OpenIssues:       1,
+			OpenPullRequests: 1,
+			FirstApplied:     date(4),
+			LastApplied:      date(4),
+			CoOccurrences:    CoOccurrences{{"bug", 1}},
+		},
+		{
+			Label: labels[2],
+		},
+	}
+
+	got := ComputeStats(labels, issues, events)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ComputeStats() = %+v, expected %+v", got, want)
+	}
+}
+
+func Test_median(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      time.Duration
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:      "odd",
+			durations: []time.Duration{3, 1, 2},
+			want:      2,
+		},
+		{
+			name:      "even",
+			durations: []time.Duration{4, 1, 2, 3},
+			want:      2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := median(tt.durations); got != tt.want {
+				t.Errorf("median() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoOccurrences_String(t *testing.T) {
+	c := CoOccurrences{{"bug", 3}, {"needs-triage", 1}}
+	if got, want := c.String(), "bug (3); needs-triage (1)"; got != want {
+		t.Errorf("String() = %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,211 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	cliutils "github.com/cli/cli/utils"
+	"github.com/heaths/gh-label/internal/analysis"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+)
+
+type analyzeOptions struct {
+	minJaccard float64
+	minCount   int
+	matrix     bool
+	format     string
+
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+func AnalyzeCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &analyzeOptions{}
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Find labels that may be redundant and suggest which to merge",
+		Long: heredoc.Doc(`
+			Find labels that may be redundant and suggest which to merge.
+
+			Pairs of labels are reported when their names differ only in case, punctuation, plurality,
+			or a scope like "bug" and "type: bug"; when their names are nearly the same like "enhancement"
+			and "enhancment"; when their descriptions are nearly the same; or when the Jaccard similarity of
+			the issues and pull requests carrying them - the number carrying both divided by the number
+			carrying either - is at least --min-jaccard.
+
+			The label applied to more issues and pull requests is suggested to keep. To merge labels, run:
+
+			  gh label apply --search 'label:"FROM"' --add INTO --remove FROM
+			  gh label delete FROM
+
+			Pass --matrix to show how many issues and pull requests carry each pair of labels instead.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label analyze
+			$ gh label analyze --min-jaccard 0.5 --min-count 10
+			$ gh label analyze --matrix --format json
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.format != "table" && opts.format != "json" {
+				return fmt.Errorf(`invalid flag "format": expected "table" or "json", got %q`, opts.format)
+			}
+
+			if opts.minJaccard < 0 || opts.minJaccard > 1 {
+				return fmt.Errorf(`invalid flag "min-jaccard": expected a number from 0 to 1, got %v`, opts.minJaccard)
+			}
+
+			if opts.minCount < 1 {
+				return fmt.Errorf(`invalid flag "min-count": expected a number greater than 0, got %d`, opts.minCount)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return analyze(globalOpts, opts)
+		},
+	}
+
+	defaults := analysis.DefaultOptions()
+	cmd.Flags().Float64VarP(&opts.minJaccard, "min-jaccard", "", defaults.MinJaccard, "Minimum Jaccard similarity from 0 to 1 for labels usually applied together.")
+	cmd.Flags().IntVarP(&opts.minCount, "min-count", "", defaults.MinCount, "Minimum number of issues and pull requests carrying both labels before their Jaccard similarity is considered.")
+	cmd.Flags().BoolVarP(&opts.matrix, "matrix", "", false, "Show how many issues and pull requests carry each pair of labels.")
+	cmd.Flags().StringVarP(&opts.format, "format", "", "table", `Format of the findings: "table" or "json".`)
+
+	return cmd
+}
+
+func analyze(globalOpts *options.GlobalOptions, opts *analyzeOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	labels, err := opts.client.ListLabels("")
+	if err != nil {
+		return fmt.Errorf("failed to list labels; error: %w", err)
+	}
+
+	issues, err := opts.client.ListIssues("all")
+	if err != nil {
+		return fmt.Errorf("failed to list issues; error: %w", err)
+	}
+
+	m := analysis.CoOccurrenceMatrix(labels, issues)
+
+	if opts.matrix {
+		m = used(m)
+		if opts.format == "json" {
+			return writeJSON(opts.io, m)
+		}
+
+		return writeMatrix(opts.io, m)
+	}
+
+	analysisOpts := analysis.DefaultOptions()
+	analysisOpts.MinJaccard = opts.minJaccard
+	analysisOpts.MinCount = opts.minCount
+
+	redundancies := analysis.Redundancies(labels, m, analysisOpts)
+	if opts.format == "json" {
+		if redundancies == nil {
+			redundancies = []analysis.Redundancy{}
+		}
+		return writeJSON(opts.io, redundancies)
+	}
+
+	return writeTable(opts.io, redundancies)
+}
+
+// used returns a matrix of only labels applied to at least one issue or pull request.
+func used(m analysis.Matrix) analysis.Matrix {
+	var indexes []int
+	for i := range m.Labels {
+		if m.Counts[i][i] > 0 {
+			indexes = append(indexes, i)
+		}
+	}
+
+	u := analysis.Matrix{
+		Labels: make([]string, len(indexes)),
+		Counts: make([][]int, len(indexes)),
+	}
+	for i, index := range indexes {
+		u.Labels[i] = m.Labels[index]
+		u.Counts[i] = make([]int, len(indexes))
+		for j, other := range indexes {
+			u.Counts[i][j] = m.Counts[index][other]
+		}
+	}
+
+	return u
+}
+
+func writeJSON(io *iostreams.IOStreams, v interface{}) error {
+	enc := json.NewEncoder(io.Out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeMatrix(io *iostreams.IOStreams, m analysis.Matrix) error {
+	cs := io.ColorScheme()
+
+	if io.IsStdoutTTY() {
+		fmt.Fprintf(io.Out, "Showing %d labels applied to issues or pull requests\n\n", len(m.Labels))
+	}
+
+	printer := cliutils.NewTablePrinter(io)
+	printer.AddField("", nil, nil)
+	for _, label := range m.Labels {
+		printer.AddField(label, nil, cs.Bold)
+	}
+	printer.EndRow()
+
+	for i, label := range m.Labels {
+		printer.AddField(label, nil, cs.Bold)
+		for _, count := range m.Counts[i] {
+			printer.AddField(strconv.Itoa(count), nil, nil)
+		}
+		printer.EndRow()
+	}
+	return printer.Render()
+}
+
+func writeTable(io *iostreams.IOStreams, redundancies []analysis.Redundancy) error {
+	cs := io.ColorScheme()
+
+	if io.IsStdoutTTY() {
+		if len(redundancies) == 0 {
+			fmt.Fprintln(io.Out, "No redundant labels found")
+			return nil
+		}
+
+		fmt.Fprintf(io.Out, "Found %d pair(s) of labels that may be redundant\n\n", len(redundancies))
+	}
+
+	printer := cliutils.NewTablePrinter(io)
+	for _, r := range redundancies {
+		printer.AddField(fmt.Sprintf("'%s', '%s'", r.Labels[0], r.Labels[1]), nil, nil)
+		printer.AddField(strings.Join(r.Reasons, ", "), nil, nil)
+		printer.AddField(fmt.Sprintf("%d together, %.2f similar", r.Together, r.Jaccard), nil, nil)
+		printer.AddField(fmt.Sprintf("merge '%s' into '%s'", r.From(), r.Into), nil, cs.ColorFromString("gray"))
+		printer.EndRow()
+	}
+	return printer.Render()
+}
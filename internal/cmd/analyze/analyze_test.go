@@ -0,0 +1,113 @@
+package analyze
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+)
+
+// analyzeMock returns labels and issues.
+type analyzeMock struct {
+	github.Mock
+}
+
+func (m *analyzeMock) ListLabels(substr string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`{"data":{"repository":{"labels":{"nodes":[
+		{"name": "bug", "color": "d73a4a"},
+		{"name": "type: bug", "color": "d73a4a"},
+		{"name": "needs-triage", "color": "fbca04"},
+		{"name": "new", "color": "ededed"},
+		{"name": "unused", "color": "ffffff"}
+	]}}}}`), nil
+}
+
+func (m *analyzeMock) ListIssues(state string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`[
+		{"number": 1, "labels": [{"name": "needs-triage"}, {"name": "new"}]},
+		{"number": 2, "labels": [{"name": "needs-triage"}, {"name": "new"}]},
+		{"number": 3, "labels": [{"name": "needs-triage"}, {"name": "new"}, {"name": "type: bug"}]}
+	]`), nil
+}
+
+func Test_analyze(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  analyzeOptions
+		wantW string
+	}{
+		{
+			name: "table",
+			opts: analyzeOptions{
+				minJaccard: 0.8,
+				minCount:   3,
+				format:     "table",
+			},
+			wantW: heredoc.Docf(`
+				'needs-triage', 'new'%[1]sco-occurrence%[1]s3 together, 1.00 similar%[1]smerge 'new' into 'needs-triage'
+				'bug', 'type: bug'%[1]sscope%[1]s0 together, 0.00 similar%[1]smerge 'bug' into 'type: bug'
+			`, "\t"),
+		},
+		{
+			name: "min count",
+			opts: analyzeOptions{
+				minJaccard: 0.8,
+				minCount:   4,
+				format:     "json",
+			},
+			wantW: heredoc.Doc(`
+				[
+				  {
+				    "labels": [
+				      "bug",
+				      "type: bug"
+				    ],
+				    "reasons": [
+				      "scope"
+				    ],
+				    "together": 0,
+				    "jaccard": 0,
+				    "into": "type: bug"
+				  }
+				]
+			`),
+		},
+		{
+			name: "matrix",
+			opts: analyzeOptions{
+				minJaccard: 0.8,
+				minCount:   3,
+				matrix:     true,
+				format:     "table",
+			},
+			wantW: heredoc.Docf(`
+				%[1]stype: bug%[1]sneeds-triage%[1]snew
+				type: bug%[1]s1%[1]s1%[1]s1
+				needs-triage%[1]s1%[1]s3%[1]s3
+				new%[1]s1%[1]s3%[1]s3
+			`, "\t"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+
+			opts := tt.opts
+			opts.client = github.New(&analyzeMock{})
+			opts.io = io
+
+			if err := analyze(&options.GlobalOptions{}, &opts); err != nil {
+				t.Errorf("analyze() error = %v", err)
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("analyze() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package browse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultRows is the number of labels shown at once if the terminal height is unknown.
+const defaultRows = 20
+
+type browseOptions struct {
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+func BrowseCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &browseOptions{}
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Interactively filter, edit, and delete labels in the repository",
+		Long: heredoc.Doc(`
+			Interactively filter, edit, and delete labels in the repository.
+
+			Type to filter labels by name or description, and use the up and down arrows to move.
+			Press enter to edit the name, color, and description of a label, with a preview of the color as you type.
+			Press tab to select labels, and delete or ctrl+d to mark the selected labels, or the current label, to delete.
+
+			Changes are not applied until you review them with ctrl+s, or esc when the filter is empty, and confirm.
+			Press ctrl+c to quit without applying changes.
+
+			Running gh label without a command in a terminal also browses labels.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label browse
+			$ gh label
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return browse(globalOpts, opts)
+		},
+	}
+
+	return cmd
+}
+
+func browse(globalOpts *options.GlobalOptions, opts *browseOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	if !opts.io.CanPrompt() {
+		return errors.New("browsing labels requires a terminal; use list, edit, or delete instead")
+	}
+
+	labels, err := opts.client.ListLabels("")
+	if err != nil {
+		return fmt.Errorf("failed to list labels; error: %w", err)
+	}
+
+	rows := defaultRows
+	if f, ok := opts.io.Out.(*os.File); ok {
+		// Leave room for the filter, status, and help.
+		if _, height, err := term.GetSize(int(f.Fd())); err == nil && height > 10 {
+			rows = height - 8
+		}
+	}
+
+	m := newModel(labels, rows)
+	result, err := run(opts.io, m)
+	if err != nil {
+		return err
+	}
+
+	pending := m.pending()
+	if result != apply {
+		if len(pending) > 0 {
+			fmt.Fprintf(opts.io.Out, "Discarded %d pending change(s)\n", len(pending))
+		}
+		return nil
+	}
+
+	return applyChanges(opts, pending)
+}
+
+// run reads keys and renders the model until the user quits or applies changes.
+// If stdin is a terminal, it is put in raw mode and the alternate screen is used until run returns.
+func run(streams *iostreams.IOStreams, m *model) (result, error) {
+	out := streams.Out
+	newline := "\n"
+
+	if f, ok := streams.In.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return none, fmt.Errorf("failed to read from terminal; error: %w", err)
+		}
+		defer func() {
+			_ = term.Restore(int(f.Fd()), state)
+		}()
+
+		// Raw mode does not return the carriage on a new line.
+		newline = "\r\n"
+	}
+
+	fmt.Fprint(out, "\x1b[?1049h")
+	defer fmt.Fprint(out, "\x1b[?1049l")
+
+	cs := streams.ColorScheme()
+	r := bufio.NewReader(streams.In)
+
+	for {
+		fmt.Fprint(out, "\x1b[H\x1b[2J", strings.ReplaceAll(m.view(cs), "\n", newline))
+
+		k, err := readKey(r)
+		if err == io.EOF {
+			return quit, nil
+		}
+		if err != nil {
+			return none, fmt.Errorf("failed to read from terminal; error: %w", err)
+		}
+
+		if result := m.update(k); result != none {
+			return result, nil
+		}
+	}
+}
+
+// applyChanges deletes or updates labels and returns an error if any failed.
+func applyChanges(opts *browseOptions, pending []*item) error {
+	failed := 0
+	for _, item := range pending {
+		if item.deleted {
+			if err := opts.client.DeleteLabel(item.label.Name); err != nil {
+				fmt.Fprintf(opts.io.ErrOut, "Failed to delete label '%s': %s\n", item.label.Name, err)
+				failed++
+				continue
+			}
+
+			fmt.Fprintf(opts.io.Out, "Deleted label '%s'\n", item.label.Name)
+			continue
+		}
+
+		label := github.EditLabel{
+			Label: github.Label{
+				Name: item.label.Name,
+			},
+		}
+		if item.edited.Color != item.label.Color {
+			label.Color = item.edited.Color
+		}
+		if item.edited.Description != item.label.Description {
+			label.Description = item.edited.Description
+		}
+		if item.edited.Name != item.label.Name {
+			label.NewName = item.edited.Name
+		}
+
+		updated, err := opts.client.UpdateLabel(label)
+		if err != nil {
+			fmt.Fprintf(opts.io.ErrOut, "Failed to update label '%s': %s\n", item.label.Name, err)
+			failed++
+			continue
+		}
+
+		if label.Name != updated.Name {
+			fmt.Fprintf(opts.io.Out, "Renamed label '%s' to '%s'\n", label.Name, updated.Name)
+		} else {
+			fmt.Fprintf(opts.io.Out, "Updated label '%s'\n", updated.Name)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply changes to %d of %d label(s)", failed, len(pending))
+	}
+
+	return nil
+}
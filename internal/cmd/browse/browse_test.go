@@ -0,0 +1,282 @@
+package browse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+)
+
+// browseMock records labels updated or deleted.
+type browseMock struct {
+	github.Mock
+
+	failing string
+	updated []string
+	deleted []string
+}
+
+func (m *browseMock) ListLabels(substr string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`{"data":{"repository":{"labels":{"nodes":[
+		{"name": "bug", "color": "d73a4a", "description": "Something isn't working"},
+		{"name": "documentation", "color": "0075ca", "description": "Improvements or additions to documentation"},
+		{"name": "duplicate", "color": "cfd3d7", "description": "This issue or pull request already exists"},
+		{"name": "wontfix", "color": "ffffff", "description": "This will not be worked on"}
+	]}}}}`), nil
+}
+
+func (m *browseMock) UpdateLabel(label github.EditLabel) (bytes.Buffer, error) {
+	if label.Name == m.failing {
+		return bytes.Buffer{}, errors.New("HTTP 422")
+	}
+
+	m.updated = append(m.updated, fmt.Sprintf("%s: color=%s description=%s new_name=%s", label.Name, label.Color, label.Description, label.NewName))
+
+	name := label.Name
+	if label.NewName != "" {
+		name = label.NewName
+	}
+	return *bytes.NewBufferString(fmt.Sprintf(`{"name": %q, "color": %q}`, name, label.Color)), nil
+}
+
+func (m *browseMock) DeleteLabel(name string) error {
+	if name == m.failing {
+		return errors.New("HTTP 404")
+	}
+
+	m.deleted = append(m.deleted, name)
+	return nil
+}
+
+const (
+	up        = "\x1b[A"
+	down      = "\x1b[B"
+	del       = "\x1b[3~"
+	escape    = "\x1b"
+	backspace = "\x7f"
+	ctrlC     = "\x03"
+	ctrlS     = "\x13"
+)
+
+func Test_browse(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		failing     string
+		tty         bool
+		wantUpdated []string
+		wantDeleted []string
+		wantW       string
+		wantE       bool
+	}{
+		{
+			name:  "not a terminal",
+			wantE: true,
+		},
+		{
+			name:  "quit",
+			input: escape,
+			tty:   true,
+		},
+		{
+			name: "edit and delete",
+			// Rename "documentation" to "docs" with a new color, then delete "duplicate" and "wontfix".
+			input: "doc\r" + strings.Repeat(backspace, 10) + "s\t" + strings.Repeat(backspace, 6) + "blue\r" +
+				escape + down + down + "\t\t" + del + ctrlS + "y",
+			tty: true,
+			wantUpdated: []string{
+				"documentation: color=0000FF description= new_name=docs",
+			},
+			wantDeleted: []string{"duplicate", "wontfix"},
+			wantW:       "Renamed label 'documentation' to 'docs'\nDeleted label 'duplicate'\nDeleted label 'wontfix'\n",
+		},
+		{
+			name:  "review and cancel",
+			input: "bug" + del + escape + escape + "n" + ctrlC,
+			tty:   true,
+			wantW: "Discarded 1 pending change(s)\n",
+		},
+		{
+			name:    "failed",
+			input:   "wontfix" + del + escape + escape + "\r",
+			failing: "wontfix",
+			tty:     true,
+			wantE:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, stdout, _ := iostreams.Test()
+			io.SetStdinTTY(tt.tty)
+			io.SetStdoutTTY(tt.tty)
+			stdin.WriteString(tt.input)
+
+			mock := &browseMock{failing: tt.failing}
+			opts := &browseOptions{
+				client: github.New(mock),
+				io:     io,
+			}
+
+			if err := browse(&options.GlobalOptions{}, opts); (err != nil) != tt.wantE {
+				t.Errorf("browse() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(mock.updated, tt.wantUpdated) {
+				t.Errorf("updated = %v, expected %v", mock.updated, tt.wantUpdated)
+			}
+
+			if !reflect.DeepEqual(mock.deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, expected %v", mock.deleted, tt.wantDeleted)
+			}
+
+			// Results are written after leaving the alternate screen.
+			gotW := stdout.String()
+			if i := strings.LastIndex(gotW, "\x1b[?1049l"); i >= 0 {
+				gotW = gotW[i+len("\x1b[?1049l"):]
+			}
+			if gotW != tt.wantW {
+				t.Errorf("browse() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func Test_readKey(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("a\r\t" + backspace + up + down + del + "\x1b[C" + ctrlC + ctrlS + "\x04é" + escape))
+
+	want := []key{
+		{typ: keyRune, rune: 'a'},
+		{typ: keyEnter},
+		{typ: keyTab},
+		{typ: keyBackspace},
+		{typ: keyUp},
+		{typ: keyDown},
+		{typ: keyDelete},
+		{typ: keyUnknown},
+		{typ: keyCtrlC},
+		{typ: keyCtrlS},
+		{typ: keyDelete},
+		{typ: keyRune, rune: 'é'},
+		{typ: keyEscape},
+	}
+
+	for i, w := range want {
+		got, err := readKey(r)
+		if err != nil {
+			t.Fatalf("readKey() error = %v", err)
+		}
+		if got != w {
+			t.Errorf("readKey() key %d = %+v, expected %+v", i, got, w)
+		}
+	}
+}
+
+func Test_model(t *testing.T) {
+	labels := github.Labels{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "feature", Color: "a2eeef"},
+		{Name: "question", Color: "d876e3", Description: "Further information is requested"},
+	}
+
+	cs := iostreams.NewColorScheme(false, false, false)
+
+	t.Run("filter", func(t *testing.T) {
+		m := newModel(labels, defaultRows)
+		for _, r := range "INFO" {
+			m.update(key{typ: keyRune, rune: r})
+		}
+
+		want := strings.Join([]string{
+			"Filter: INFO",
+			"Showing 1 of 3 labels, 0 pending change(s)",
+			"",
+			"> [ ]   question #d876e3 Further information is requested",
+			"",
+		}, "\n")
+		if got := m.view(cs); !strings.HasPrefix(got, want) {
+			t.Errorf("view() = %q, expected prefix %q", got, want)
+		}
+	})
+
+	t.Run("invalid edits", func(t *testing.T) {
+		m := newModel(labels, defaultRows)
+		m.update(key{typ: keyEnter})
+
+		// Rename "bug" to "Feature".
+		for i := 0; i < 3; i++ {
+			m.update(key{typ: keyBackspace})
+		}
+		for _, r := range "Feature" {
+			m.update(key{typ: keyRune, rune: r})
+		}
+		m.update(key{typ: keyEnter})
+		if want := "Label 'feature' already exists"; m.message != want {
+			t.Errorf("message = %q, expected %q", m.message, want)
+		}
+
+		// Restore the name and set an invalid color.
+		m.fields[nameField] = "bug"
+		m.fields[colorField] = "nope"
+		m.update(key{typ: keyEnter})
+		if !strings.HasPrefix(m.message, "Color is invalid") {
+			t.Errorf("message = %q, expected color to be invalid", m.message)
+		}
+		if got := m.view(cs); !strings.Contains(got, "Preview:     invalid color") {
+			t.Errorf("view() = %q, expected invalid color preview", got)
+		}
+
+		// Clear the description.
+		m.fields[colorField] = "D73A4A"
+		m.fields[descriptionField] = ""
+		m.update(key{typ: keyEnter})
+		if want := "Description cannot be cleared"; m.message != want {
+			t.Errorf("message = %q, expected %q", m.message, want)
+		}
+
+		m.update(key{typ: keyEscape})
+		if m.mode != listMode || len(m.pending()) != 0 {
+			t.Errorf("expected list mode without pending changes, got mode %d with %d change(s)", m.mode, len(m.pending()))
+		}
+	})
+
+	t.Run("review", func(t *testing.T) {
+		m := newModel(labels, defaultRows)
+		if m.update(key{typ: keyCtrlS}); m.message != "No pending changes" {
+			t.Errorf("message = %q, expected no pending changes", m.message)
+		}
+
+		// Select "bug" and edit "feature", then delete the selected label.
+		m.update(key{typ: keyTab})
+		m.update(key{typ: keyEnter})
+		m.fields[colorField] = "#00ff00"
+		m.update(key{typ: keyEnter})
+		m.update(key{typ: keyDelete})
+		m.update(key{typ: keyEscape})
+
+		want := strings.Join([]string{
+			"Review 2 pending change(s)",
+			"",
+			"  Delete label 'bug'",
+			"  Change color of 'feature' from #a2eeef to #00ff00",
+			"",
+			"Apply changes? (y/n, ctrl+c to quit without applying)",
+			"",
+		}, "\n")
+		if got := m.view(cs); got != want {
+			t.Errorf("view() = %q, expected %q", got, want)
+		}
+
+		if got := m.update(key{typ: keyRune, rune: 'y'}); got != apply {
+			t.Errorf("update() = %v, expected apply", got)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package browse
+
+import (
+	"bufio"
+	"unicode"
+)
+
+type keyType int
+
+const (
+	keyRune keyType = iota
+	keyUp
+	keyDown
+	keyEnter
+	keyTab
+	keyBackspace
+	keyDelete
+	keyEscape
+	keyCtrlC
+	keyCtrlS
+	keyUnknown
+)
+
+// key is a key pressed, or a rune typed if its type is keyRune.
+type key struct {
+	typ  keyType
+	rune rune
+}
+
+// readKey reads the next key from r, which should read from a terminal in raw mode.
+// An escape not immediately followed by a control sequence is keyEscape.
+func readKey(r *bufio.Reader) (key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+
+	switch c {
+	case '\r', '\n':
+		return key{typ: keyEnter}, nil
+	case '\t':
+		return key{typ: keyTab}, nil
+	case 0x7f, 0x08:
+		return key{typ: keyBackspace}, nil
+	case 0x03:
+		return key{typ: keyCtrlC}, nil
+	case 0x04:
+		return key{typ: keyDelete}, nil
+	case 0x13:
+		return key{typ: keyCtrlS}, nil
+	case 0x1b:
+		return readSequence(r)
+	}
+
+	if !unicode.IsPrint(c) {
+		return key{typ: keyUnknown}, nil
+	}
+
+	return key{typ: keyRune, rune: c}, nil
+}
+
+// readSequence reads a control sequence like "\x1b[A" following an escape.
+func readSequence(r *bufio.Reader) (key, error) {
+	if r.Buffered() == 0 {
+		return key{typ: keyEscape}, nil
+	}
+
+	if b, _ := r.Peek(1); b[0] != '[' && b[0] != 'O' {
+		return key{typ: keyEscape}, nil
+	}
+	_, _ = r.ReadByte()
+
+	// Read parameters until the final byte.
+	var params []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return key{}, err
+		}
+
+		if b >= 0x40 && b <= 0x7e {
+			switch {
+			case b == 'A':
+				return key{typ: keyUp}, nil
+			case b == 'B':
+				return key{typ: keyDown}, nil
+			case b == '~' && string(params) == "3":
+				return key{typ: keyDelete}, nil
+			}
+			return key{typ: keyUnknown}, nil
+		}
+
+		params = append(params, b)
+	}
+}
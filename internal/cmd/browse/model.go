@@ -0,0 +1,416 @@
+package browse
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/utils"
+)
+
+type mode int
+
+const (
+	listMode mode = iota
+	editMode
+	reviewMode
+)
+
+// result is what to do after a key is handled.
+type result int
+
+const (
+	none result = iota
+	quit
+	apply
+)
+
+// Fields of a label in edit mode.
+const (
+	nameField = iota
+	colorField
+	descriptionField
+	fieldCount
+)
+
+var fieldNames = [fieldCount]string{"Name", "Color", "Description"}
+
+// item is a label in the repository and any pending changes to it.
+type item struct {
+	label    github.Label
+	edited   github.Label
+	deleted  bool
+	selected bool
+}
+
+func (i *item) changed() bool {
+	return i.deleted || i.edited != i.label
+}
+
+// model is the state of the browser, updated by keys and rendered by view.
+type model struct {
+	items  []*item
+	filter string
+	cursor int
+	rows   int
+	mode   mode
+
+	// edit mode
+	editing *item
+	fields  [fieldCount]string
+	field   int
+
+	// message is shown below the list until the next key.
+	message string
+}
+
+func newModel(labels github.Labels, rows int) *model {
+	m := &model{
+		items: make([]*item, len(labels)),
+		rows:  rows,
+	}
+	for i, label := range labels {
+		m.items[i] = &item{label: label, edited: label}
+	}
+	return m
+}
+
+// visible returns items with the filter in their name or description without regard to case.
+func (m *model) visible() []*item {
+	filter := strings.ToLower(m.filter)
+
+	var visible []*item
+	for _, item := range m.items {
+		if filter == "" ||
+			strings.Contains(strings.ToLower(item.edited.Name), filter) ||
+			strings.Contains(strings.ToLower(item.edited.Description), filter) {
+			visible = append(visible, item)
+		}
+	}
+	return visible
+}
+
+// pending returns items with changes in their original order.
+func (m *model) pending() []*item {
+	var pending []*item
+	for _, item := range m.items {
+		if item.changed() {
+			pending = append(pending, item)
+		}
+	}
+	return pending
+}
+
+func (m *model) current() *item {
+	visible := m.visible()
+	if m.cursor < 0 || m.cursor >= len(visible) {
+		return nil
+	}
+	return visible[m.cursor]
+}
+
+// update handles a key and returns what to do next.
+func (m *model) update(k key) result {
+	m.message = ""
+
+	switch m.mode {
+	case editMode:
+		m.updateEdit(k)
+	case reviewMode:
+		return m.updateReview(k)
+	default:
+		return m.updateList(k)
+	}
+
+	return none
+}
+
+func (m *model) updateList(k key) result {
+	switch k.typ {
+	case keyCtrlC:
+		return quit
+	case keyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case keyDown:
+		if m.cursor < len(m.visible())-1 {
+			m.cursor++
+		}
+	case keyRune:
+		m.filter += string(k.rune)
+		m.cursor = 0
+	case keyBackspace:
+		if m.filter != "" {
+			_, size := utf8.DecodeLastRuneInString(m.filter)
+			m.filter = m.filter[:len(m.filter)-size]
+			m.cursor = 0
+		}
+	case keyEscape:
+		if m.filter != "" {
+			m.filter = ""
+			m.cursor = 0
+		} else if len(m.pending()) > 0 {
+			m.mode = reviewMode
+		} else {
+			return quit
+		}
+	case keyTab:
+		if item := m.current(); item != nil {
+			item.selected = !item.selected
+			if m.cursor < len(m.visible())-1 {
+				m.cursor++
+			}
+		}
+	case keyDelete:
+		m.toggleDeleted()
+	case keyEnter:
+		if item := m.current(); item != nil {
+			m.mode = editMode
+			m.editing = item
+			m.fields = [fieldCount]string{item.edited.Name, item.edited.Color, item.edited.Description}
+			m.field = nameField
+		}
+	case keyCtrlS:
+		if len(m.pending()) == 0 {
+			m.message = "No pending changes"
+		} else {
+			m.mode = reviewMode
+		}
+	}
+
+	return none
+}
+
+// toggleDeleted marks selected labels, or the current label if none are selected, to be deleted or not.
+func (m *model) toggleDeleted() {
+	var items []*item
+	for _, item := range m.items {
+		if item.selected {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		if item := m.current(); item != nil {
+			items = append(items, item)
+		}
+	}
+
+	for _, item := range items {
+		item.deleted = !item.deleted
+		item.selected = false
+	}
+}
+
+func (m *model) updateEdit(k key) {
+	switch k.typ {
+	case keyEscape, keyCtrlC:
+		m.mode = listMode
+		m.editing = nil
+	case keyUp:
+		m.field = (m.field + fieldCount - 1) % fieldCount
+	case keyDown, keyTab:
+		m.field = (m.field + 1) % fieldCount
+	case keyRune:
+		m.fields[m.field] += string(k.rune)
+	case keyBackspace:
+		if s := m.fields[m.field]; s != "" {
+			_, size := utf8.DecodeLastRuneInString(s)
+			m.fields[m.field] = s[:len(s)-size]
+		}
+	case keyEnter:
+		edited, message := m.validate()
+		if message != "" {
+			m.message = message
+			return
+		}
+
+		m.editing.edited = edited
+		m.mode = listMode
+		m.editing = nil
+	}
+}
+
+// validate returns the edited label, or a message if a field is invalid.
+func (m *model) validate() (github.Label, string) {
+	edited := m.editing.edited
+
+	name := strings.TrimSpace(m.fields[nameField])
+	if name == "" {
+		return edited, "Name is required"
+	}
+	for _, item := range m.items {
+		if item != m.editing && strings.EqualFold(item.edited.Name, name) {
+			return edited, fmt.Sprintf("Label '%s' already exists", item.edited.Name)
+		}
+	}
+
+	color, err := utils.ValidateColor(m.fields[colorField])
+	if err != nil {
+		return edited, fmt.Sprintf("Color is invalid: %s", err)
+	}
+
+	description := strings.TrimSpace(m.fields[descriptionField])
+	if description == "" && m.editing.label.Description != "" {
+		return edited, "Description cannot be cleared"
+	}
+	if n := utf8.RuneCountInString(description); n > github.MaxDescriptionLength {
+		return edited, fmt.Sprintf("Description is %d characters, more than %d", n, github.MaxDescriptionLength)
+	}
+
+	// Keep the original color if only its case or format changed.
+	if strings.EqualFold(color, edited.Color) {
+		color = edited.Color
+	}
+
+	edited.Name = name
+	edited.Color = color
+	edited.Description = description
+	return edited, ""
+}
+
+func (m *model) updateReview(k key) result {
+	switch k.typ {
+	case keyCtrlC:
+		return quit
+	case keyEnter:
+		return apply
+	case keyEscape:
+		m.mode = listMode
+	case keyRune:
+		switch k.rune {
+		case 'y', 'Y':
+			return apply
+		case 'n', 'N':
+			m.mode = listMode
+		}
+	}
+
+	return none
+}
+
+// changes describes pending changes to an item.
+func changes(item *item) []string {
+	if item.deleted {
+		return []string{fmt.Sprintf("Delete label '%s'", item.label.Name)}
+	}
+
+	var changes []string
+	if item.edited.Name != item.label.Name {
+		changes = append(changes, fmt.Sprintf("Rename label '%s' to '%s'", item.label.Name, item.edited.Name))
+	}
+	if item.edited.Color != item.label.Color {
+		changes = append(changes, fmt.Sprintf("Change color of '%s' from #%s to #%s", item.edited.Name, item.label.Color, item.edited.Color))
+	}
+	if item.edited.Description != item.label.Description {
+		changes = append(changes, fmt.Sprintf("Change description of '%s' to '%s'", item.edited.Name, item.edited.Description))
+	}
+	return changes
+}
+
+// view renders the current state.
+func (m *model) view(cs *iostreams.ColorScheme) string {
+	var sb strings.Builder
+
+	switch m.mode {
+	case editMode:
+		m.viewEdit(&sb, cs)
+	case reviewMode:
+		m.viewReview(&sb, cs)
+	default:
+		m.viewList(&sb, cs)
+	}
+
+	if m.message != "" {
+		fmt.Fprintf(&sb, "\n%s\n", cs.Red(m.message))
+	}
+
+	return sb.String()
+}
+
+func (m *model) viewList(sb *strings.Builder, cs *iostreams.ColorScheme) {
+	visible := m.visible()
+	fmt.Fprintf(sb, "Filter: %s\n", m.filter)
+	fmt.Fprintf(sb, "Showing %d of %d labels, %d pending change(s)\n\n", len(visible), len(m.items), len(m.pending()))
+
+	// Scroll to keep the cursor visible.
+	start := 0
+	if m.rows > 0 && m.cursor >= m.rows {
+		start = m.cursor - m.rows + 1
+	}
+	end := len(visible)
+	if m.rows > 0 && end > start+m.rows {
+		end = start + m.rows
+	}
+
+	for i := start; i < end; i++ {
+		item := visible[i]
+
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+
+		selected := "[ ]"
+		if item.selected {
+			selected = "[x]"
+		}
+
+		status := " "
+		switch {
+		case item.deleted:
+			status = "-"
+		case item.changed():
+			status = "*"
+		}
+
+		name := cs.HexToRGB(item.edited.Color, item.edited.Name)
+		if item.deleted {
+			name = cs.Gray(item.edited.Name)
+		}
+
+		fmt.Fprintf(sb, "%s %s %s %s #%s %s\n", cursor, selected, status, name, item.edited.Color, cs.Gray(item.edited.Description))
+	}
+
+	fmt.Fprintf(sb, "\n%s\n", cs.Gray("Type to filter, up/down to move, tab to select, enter to edit, delete or ctrl+d to delete, ctrl+s to review changes, esc to clear the filter or quit"))
+}
+
+func (m *model) viewEdit(sb *strings.Builder, cs *iostreams.ColorScheme) {
+	fmt.Fprintf(sb, "Edit label '%s'\n\n", m.editing.label.Name)
+
+	for i, name := range fieldNames {
+		cursor := " "
+		if i == m.field {
+			cursor = ">"
+		}
+		fmt.Fprintf(sb, "%s %-12s %s\n", cursor, name+":", m.fields[i])
+	}
+
+	// Preview the color as it is typed.
+	if color, err := utils.ValidateColor(m.fields[colorField]); err == nil {
+		name := m.fields[nameField]
+		if name == "" {
+			name = m.editing.label.Name
+		}
+		fmt.Fprintf(sb, "\n  Preview:     %s #%s\n", cs.HexToRGB(color, name), color)
+	} else {
+		fmt.Fprintf(sb, "\n  Preview:     %s\n", cs.Gray("invalid color"))
+	}
+
+	fmt.Fprintf(sb, "\n%s\n", cs.Gray("Type to edit, up/down or tab to change fields, enter to save, esc to cancel"))
+}
+
+func (m *model) viewReview(sb *strings.Builder, cs *iostreams.ColorScheme) {
+	pending := m.pending()
+	fmt.Fprintf(sb, "Review %d pending change(s)\n\n", len(pending))
+
+	for _, item := range pending {
+		for _, change := range changes(item) {
+			fmt.Fprintf(sb, "  %s\n", change)
+		}
+	}
+
+	fmt.Fprintf(sb, "\nApply changes? %s\n", cs.Gray("(y/n, ctrl+c to quit without applying)"))
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type configOptions struct {
+	// test
+	config *options.Config
+	io     *iostreams.IOStreams
+}
+
+func ConfigCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &configOptions{}
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show the effective settings from .github/gh-label.yml merged with defaults.",
+		Long: heredoc.Doc(`
+			Show the effective settings from .github/gh-label.yml merged with defaults.
+
+			The configuration is discovered in the working directory or its ancestors,
+			stopping at the root of the git repository. Command line flags override these settings.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label config
+		`),
+		Annotations: map[string]string{
+			options.OfflineAnnotation: "true",
+		},
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return config(globalOpts, opts)
+		},
+	}
+
+	return cmd
+}
+
+func config(globalOpts *options.GlobalOptions, opts *configOptions) error {
+	if opts.config == nil {
+		opts.config = globalOpts.Config()
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	if path := opts.config.Path(); path != "" {
+		fmt.Fprintf(opts.io.Out, "# %s\n", path)
+	} else {
+		fmt.Fprintln(opts.io.Out, "# defaults")
+	}
+
+	enc := yaml.NewEncoder(opts.io.Out)
+	enc.SetIndent(2)
+	if err := enc.Encode(opts.config); err != nil {
+		return fmt.Errorf("failed to write configuration; error: %w", err)
+	}
+
+	return enc.Close()
+}
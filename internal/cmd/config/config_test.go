@@ -0,0 +1,85 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/options"
+)
+
+func Test_config(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		wantW string
+	}{
+		{
+			name: "defaults",
+			wantW: heredoc.Doc(`
+				# defaults
+				import:
+				  prune: false
+				  concurrency: 1
+				export:
+				  sort: false
+				  omit-url: false
+				  omit-empty-descriptions: false
+			`),
+		},
+		{
+			name: "merged",
+			data: heredoc.Doc(`
+				source: labels.yml
+				import:
+				  prune: true
+				  keep: ["area: *"]
+				export:
+				  format: json
+				  fields: [name, color]
+			`),
+			wantW: heredoc.Doc(`
+				# defaults
+				source: labels.yml
+				import:
+				  prune: true
+				  keep:
+				    - 'area: *'
+				  concurrency: 1
+				export:
+				  format: json
+				  fields:
+				    - name
+				    - color
+				  sort: false
+				  omit-url: false
+				  omit-empty-descriptions: false
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := options.ReadConfig(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("ReadConfig() error = %v", err)
+			}
+
+			io, _, stdout, _ := iostreams.Test()
+			opts := &configOptions{
+				config: cfg,
+				io:     io,
+			}
+
+			if err := config(&options.GlobalOptions{}, opts); err != nil {
+				t.Errorf("config() error = %v", err)
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("config() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
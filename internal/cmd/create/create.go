@@ -2,7 +2,7 @@ package create
 
 import (
 	"fmt"
-	"regexp"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/pkg/iostreams"
@@ -15,7 +15,9 @@ import (
 type createOptions struct {
 	name        string
 	color       string
+	colorArg    string
 	description string
+	palette     string
 
 	// test
 	client *github.Client
@@ -31,29 +33,55 @@ func CreateCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 			$ gh label create feedback
 			$ gh label create p1 --color e00808
 			$ gh label create p2 --color "#ffa501" --description "Affects more than a few users"
+			$ gh label create enhancement --palette okabe-ito
 		`),
+		Annotations: map[string]string{
+			options.MultiRepoAnnotation: "true",
+		},
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if opts.color != "" {
 				if color, err := utils.ValidateColor(opts.color); err != nil {
 					return fmt.Errorf(`invalid flag "color": %s`, err)
 				} else {
+					if color != strings.TrimPrefix(opts.color, "#") {
+						opts.colorArg = opts.color
+					}
+
 					// Set color without "#" prefix.
 					opts.color = color
 				}
 			}
 
+			if opts.palette != "" {
+				if opts.color != "" {
+					return fmt.Errorf(`only one of flags "color" or "palette" may be specified`)
+				}
+
+				if err := utils.ValidatePalette(opts.palette); err != nil {
+					return fmt.Errorf(`invalid flag "palette": %s`, err)
+				}
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.name = args[0]
 
-			return create(globalOpts, opts)
+			return globalOpts.ForEachRepo(func(globalOpts *options.GlobalOptions) error {
+				repoOpts := *opts
+				err := create(globalOpts, &repoOpts)
+
+				// Use the same random color in every repository.
+				opts.color = repoOpts.color
+				return err
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.color, "color", "c", "", `The color of the label with or without "#" prefix. A random color will be assigned if not specified.`)
+	cmd.Flags().StringVarP(&opts.color, "color", "c", "", `The color of the label with or without "#" prefix, or a color name, rgb(), or hsl(). A random color will be assigned if not specified.`)
 	cmd.Flags().StringVarP(&opts.description, "description", "d", "", "Description of the label.")
+	cmd.Flags().StringVarP(&opts.palette, "palette", "p", "", fmt.Sprintf("Choose a random color from the palette. One of %v.", utils.PaletteNames()))
 
 	return cmd
 }
@@ -64,6 +92,7 @@ func create(globalOpts *options.GlobalOptions, opts *createOptions) error {
 		cli := &github.Cli{
 			Owner: owner,
 			Repo:  repo,
+			Host:  globalOpts.Host(),
 		}
 		opts.client = github.New(cli)
 	}
@@ -73,7 +102,15 @@ func create(globalOpts *options.GlobalOptions, opts *createOptions) error {
 	}
 
 	if opts.color == "" {
-		opts.color = utils.RandomColor()
+		if opts.palette != "" {
+			color, err := utils.RandomPaletteColor(opts.palette)
+			if err != nil {
+				return err
+			}
+			opts.color = color
+		} else {
+			opts.color = utils.RandomColor()
+		}
 	}
 
 	label := github.Label{
@@ -87,18 +124,14 @@ func create(globalOpts *options.GlobalOptions, opts *createOptions) error {
 		return fmt.Errorf("failed to create label; error: %w", err)
 	}
 
-	re := regexp.MustCompile("^https://api.([^/]+)/repos/(.*)$")
-	matches := re.FindStringSubmatch(label.URL)
-
 	if opts.io.IsStdoutTTY() {
+		if opts.colorArg != "" {
+			fmt.Fprintf(opts.io.Out, "Resolved color '%s' to #%s\n", opts.colorArg, label.Color)
+		}
 		fmt.Fprintf(opts.io.Out, "Created label '%s'\n\n", label.Name)
 	}
 
-	if len(matches) == 3 {
-		fmt.Fprintf(opts.io.Out, "https://%s/%s\n", matches[1], matches[2])
-	} else {
-		fmt.Fprintln(opts.io.Out, label.URL)
-	}
+	fmt.Fprintln(opts.io.Out, github.WebURL(label.URL))
 
 	return nil
 }
@@ -9,13 +9,15 @@ import (
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/heaths/gh-label/internal/github"
 	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/utils"
 )
 
 func Test_create(t *testing.T) {
 	tests := []struct {
-		name string
-		tty  bool
-		want string
+		name     string
+		colorArg string
+		tty      bool
+		want     string
 	}{
 		{
 			name: "create",
@@ -26,6 +28,16 @@ func Test_create(t *testing.T) {
 			tty:  true,
 			want: heredoc.Doc(`Created label 'test'
 			
+			https://github.com/heaths/gh-label/labels/test
+			`),
+		},
+		{
+			name:     "create with resolved color (TTY)",
+			colorArg: "rgb(17, 34, 51)",
+			tty:      true,
+			want: heredoc.Doc(`Resolved color 'rgb(17, 34, 51)' to #112233
+			Created label 'test'
+			
 			https://github.com/heaths/gh-label/labels/test
 			`),
 		},
@@ -53,8 +65,9 @@ func Test_create(t *testing.T) {
 
 			rootOpts := &options.GlobalOptions{}
 			opts := &createOptions{
-				name:  "test",
-				color: "112233",
+				name:     "test",
+				color:    "112233",
+				colorArg: tt.colorArg,
 
 				client: github.New(mock),
 				io:     io,
@@ -111,3 +124,42 @@ func Test_create_randomColor(t *testing.T) {
 		}
 	})
 }
+
+func Test_create_palette(t *testing.T) {
+	t.Run("create with palette color", func(t *testing.T) {
+		// Set up output streams.
+		io, _, _, _ := iostreams.Test()
+
+		// Set up gh output.
+		mock := &github.Mock{
+			Stdout: *bytes.NewBufferString(heredoc.Doc(`{
+					"url": "https://api.github.com/repos/heaths/gh-label/labels/test",
+					"name": "test",
+					"color": "d73a4a"
+			}`)),
+		}
+
+		rootOpts := &options.GlobalOptions{}
+		opts := &createOptions{
+			name:    "test",
+			palette: "github",
+
+			client: github.New(mock),
+			io:     io,
+		}
+
+		if err := create(rootOpts, opts); err != nil {
+			t.Errorf("create() error = %v", err)
+			return
+		}
+
+		colors, _ := utils.PaletteColors("github", 9)
+		for _, color := range colors {
+			if color == opts.color {
+				return
+			}
+		}
+
+		t.Errorf("expected color from palette %v, got color: %s", colors, opts.color)
+	})
+}
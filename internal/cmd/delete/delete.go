@@ -26,11 +26,17 @@ func DeleteCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 		Example: heredoc.Doc(`
 			$ gh label delete p1
 		`),
+		Annotations: map[string]string{
+			options.MultiRepoAnnotation: "true",
+		},
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.name = args[0]
 
-			return delete(globalOpts, opts)
+			return globalOpts.ForEachRepo(func(globalOpts *options.GlobalOptions) error {
+				repoOpts := *opts
+				return delete(globalOpts, &repoOpts)
+			})
 		},
 	}
 
@@ -43,6 +49,7 @@ func delete(globalOpts *options.GlobalOptions, opts *deleteOptions) error {
 		cli := &github.Cli{
 			Owner: owner,
 			Repo:  repo,
+			Host:  globalOpts.Host(),
 		}
 		opts.client = github.New(cli)
 	}
@@ -2,7 +2,7 @@ package edit
 
 import (
 	"fmt"
-	"regexp"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/pkg/iostreams"
@@ -15,6 +15,7 @@ import (
 type editOptions struct {
 	name        string
 	color       string
+	colorArg    string
 	description string
 	newName     string
 
@@ -32,12 +33,19 @@ func EditCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 			$ gh label edit general --new-name feedback
 			$ gh label edit feedback --color c046ff --description "User feedback"
 		`),
+		Annotations: map[string]string{
+			options.MultiRepoAnnotation: "true",
+		},
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if opts.color != "" {
 				if color, err := utils.ValidateColor(opts.color); err != nil {
 					return fmt.Errorf(`invalid flag "color": %s`, err)
 				} else {
+					if color != strings.TrimPrefix(opts.color, "#") {
+						opts.colorArg = opts.color
+					}
+
 					// Set color without "#" prefix.
 					opts.color = color
 				}
@@ -48,11 +56,14 @@ func EditCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.name = args[0]
 
-			return edit(globalOpts, opts)
+			return globalOpts.ForEachRepo(func(globalOpts *options.GlobalOptions) error {
+				repoOpts := *opts
+				return edit(globalOpts, &repoOpts)
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.color, "color", "c", "", `The color of the label with or without "#" prefix.`)
+	cmd.Flags().StringVarP(&opts.color, "color", "c", "", `The color of the label with or without "#" prefix, or a color name, rgb(), or hsl().`)
 	cmd.Flags().StringVarP(&opts.description, "description", "d", "", "Description of the label.")
 	cmd.Flags().StringVarP(&opts.newName, "new-name", "", "", "Rename the label to the given new name.")
 
@@ -65,6 +76,7 @@ func edit(globalOpts *options.GlobalOptions, opts *editOptions) error {
 		cli := &github.Cli{
 			Owner: owner,
 			Repo:  repo,
+			Host:  globalOpts.Host(),
 		}
 		opts.client = github.New(cli)
 	}
@@ -87,10 +99,10 @@ func edit(globalOpts *options.GlobalOptions, opts *editOptions) error {
 		return fmt.Errorf("failed to create label; error: %w", err)
 	}
 
-	re := regexp.MustCompile("^https://api.([^/]+)/repos/(.*)$")
-	matches := re.FindStringSubmatch(updated.URL)
-
 	if opts.io.IsStdoutTTY() {
+		if opts.colorArg != "" {
+			fmt.Fprintf(opts.io.Out, "Resolved color '%s' to #%s\n", opts.colorArg, updated.Color)
+		}
 		if label.Name != updated.Name {
 			fmt.Fprintf(opts.io.Out, "Renamed label '%s' to '%s'\n\n", label.Name, updated.Name)
 		} else {
@@ -98,11 +110,7 @@ func edit(globalOpts *options.GlobalOptions, opts *editOptions) error {
 		}
 	}
 
-	if len(matches) == 3 {
-		fmt.Fprintf(opts.io.Out, "https://%s/%s\n", matches[1], matches[2])
-	} else {
-		fmt.Fprintln(opts.io.Out, updated.URL)
-	}
+	fmt.Fprintln(opts.io.Out, github.WebURL(updated.URL))
 
 	return nil
 }
@@ -12,10 +12,11 @@ import (
 
 func Test_edit(t *testing.T) {
 	tests := []struct {
-		name   string
-		rename string
-		tty    bool
-		want   string
+		name     string
+		rename   string
+		colorArg string
+		tty      bool
+		want     string
 	}{
 		{
 			name: "edit",
@@ -38,6 +39,16 @@ func Test_edit(t *testing.T) {
 			https://github.com/heaths/gh-label/labels/test2
 			`),
 		},
+		{
+			name:     "edit with resolved color (TTY)",
+			colorArg: "#123",
+			tty:      true,
+			want: heredoc.Doc(`Resolved color '#123' to #112233
+			Updated label 'test'
+			
+			https://github.com/heaths/gh-label/labels/test
+			`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -66,9 +77,10 @@ func Test_edit(t *testing.T) {
 
 			rootOpts := &options.GlobalOptions{}
 			opts := &editOptions{
-				name:    "test",
-				color:   "112233",
-				newName: tt.rename,
+				name:     "test",
+				color:    "112233",
+				colorArg: tt.colorArg,
+				newName:  tt.rename,
 
 				client: github.New(mock),
 				io:     io,
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/pkg/iostreams"
@@ -14,8 +15,16 @@ import (
 )
 
 type exportOptions struct {
-	path   string
-	format string
+	path         string
+	format       string
+	delimiter    string
+	groupByScope bool
+
+	sort                  bool
+	colorCase             string
+	omitURL               bool
+	omitEmptyDescriptions bool
+	fields                string
 
 	// test
 	client *github.Client
@@ -30,13 +39,45 @@ func ExportCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export <path>",
 		Short: `Export labels from the repository to <path>, or stdout if <path> is "-".`,
+		Long: heredoc.Doc(`
+			Export labels from the repository to <path>, or stdout if <path> is "-".
+
+			Any "{owner}" or "{repo}" in <path> is replaced with the owner or name of the repository,
+			which is required to export from more than one repository.
+		`),
 		Example: heredoc.Doc(`
 			$ gh label export ./labels.csv
 			$ gh label export ./labels.json
 			$ gh label export --format csv -
+			$ gh label export ./CONTRIBUTING-labels.md --group-by-scope
+			$ gh label export ./labels.svg
+			$ gh label export --repo heaths/gh-label ./labels.tf
+			$ gh label export ./labels.csv --sort --color-case lower --omit-url
+			$ gh label export ./labels.json --fields name,color,description
+			$ gh label export --repo heaths/gh-label --repo heaths/go-console "./{repo}-labels.csv"
 		`),
+		Annotations: map[string]string{
+			options.MultiRepoAnnotation: "true",
+		},
 		Args: cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			config := globalOpts.Config().Export
+			if !cmd.Flags().Changed("fields") && len(config.Fields) > 0 {
+				opts.fields = strings.Join(config.Fields, ",")
+			}
+			if !cmd.Flags().Changed("sort") {
+				opts.sort = config.Sort
+			}
+			if !cmd.Flags().Changed("color-case") {
+				opts.colorCase = config.ColorCase
+			}
+			if !cmd.Flags().Changed("omit-url") {
+				opts.omitURL = config.OmitURL
+			}
+			if !cmd.Flags().Changed("omit-empty-descriptions") {
+				opts.omitEmptyDescriptions = config.OmitEmptyDescriptions
+			}
+
 			if opts.format != "" {
 				if format, err := github.SupportedOutputFormat(opts.format); err != nil {
 					return err
@@ -49,12 +90,17 @@ func ExportCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.path = args[0]
-			if opts.path != "-" {
-				if opts.format == "" {
-					opts.format = path.Ext(opts.path)
+			if opts.format == "" && opts.path != "-" {
+				opts.format = path.Ext(opts.path)
+			}
+			if opts.format == "" {
+				opts.format = globalOpts.Config().Export.Format
+			}
+			if opts.format == "" {
+				if opts.path == "-" {
+					return fmt.Errorf(`--format is required when <path> is "-"`)
 				}
-			} else if opts.format == "" {
-				return fmt.Errorf(`--format is required when <path> is "-"`)
+				return fmt.Errorf(`--format is required when <path> has no extension`)
 			}
 
 			if format, err := github.SupportedOutputFormat(opts.format); err != nil {
@@ -63,11 +109,43 @@ func ExportCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 				opts.format = format
 			}
 
-			return export(globalOpts, opts)
+			if opts.delimiter != "" && opts.format != string(github.CSV) && opts.format != string(github.TSV) {
+				return fmt.Errorf(`flag "delimiter" is not supported for format %q`, opts.format)
+			}
+
+			if opts.fields != "" && opts.format != string(github.CSV) && opts.format != string(github.JSON) && opts.format != string(github.TSV) {
+				return fmt.Errorf(`flag "fields" is not supported for format %q`, opts.format)
+			}
+
+			targets := globalOpts.Targets()
+			if len(targets) > 1 {
+				paths := make(map[string]bool)
+				for _, target := range targets {
+					p := expandPath(opts.path, target.Owner, target.Repo)
+					if opts.path == "-" || paths[p] {
+						return fmt.Errorf(`<path> must contain "{repo}", and "{owner}" if repositories have the same name, to export from more than one repository`)
+					}
+					paths[p] = true
+				}
+			}
+
+			return globalOpts.ForEachRepo(func(globalOpts *options.GlobalOptions) error {
+				owner, repo := globalOpts.Repo()
+				repoOpts := *opts
+				repoOpts.path = expandPath(opts.path, owner, repo)
+				return export(globalOpts, &repoOpts)
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.format, "format", "", "", fmt.Sprintf("Format of the file to export. One of %v. The default is the file extension.", github.OutputFormats()))
+	cmd.Flags().StringVarP(&opts.format, "format", "", "", fmt.Sprintf("Format of the file to export. One of %v. The default is the file extension, or the configured format.", github.OutputFormats()))
+	cmd.Flags().StringVarP(&opts.delimiter, "delimiter", "", "", `Field delimiter for csv or tsv formats, like ";" or "\t". The default is "," for csv and a tab for tsv.`)
+	cmd.Flags().BoolVarP(&opts.groupByScope, "group-by-scope", "", false, `Group labels by the scope preceding ":" in their names for html or markdown formats.`)
+	cmd.Flags().BoolVarP(&opts.sort, "sort", "", false, "Sort labels by name without regard to case, comparing numbers by value.")
+	cmd.Flags().StringVarP(&opts.colorCase, "color-case", "", "", `Write colors in "lower" or "upper" case without a "#" prefix.`)
+	cmd.Flags().BoolVarP(&opts.omitURL, "omit-url", "", false, "Omit label URLs.")
+	cmd.Flags().StringVarP(&opts.fields, "fields", "", "", `Comma-separated fields to write in order for csv, json, or tsv formats, like "name,color,description". The default is all fields.`)
+	cmd.Flags().BoolVarP(&opts.omitEmptyDescriptions, "omit-empty-descriptions", "", false, "Omit the description column from csv or tsv formats if no label has a description.")
 
 	return cmd
 }
@@ -78,6 +156,7 @@ func export(globalOpts *options.GlobalOptions, opts *exportOptions) error {
 		cli := &github.Cli{
 			Owner: owner,
 			Repo:  repo,
+			Host:  globalOpts.Host(),
 		}
 		opts.client = github.New(cli)
 	}
@@ -86,6 +165,41 @@ func export(globalOpts *options.GlobalOptions, opts *exportOptions) error {
 		opts.io = iostreams.System()
 	}
 
+	// Normalized labels are always sorted so identical labels are exported identically.
+	normalize := opts.colorCase != "" || opts.omitURL || opts.omitEmptyDescriptions
+
+	formatOpts := github.FormatOptions{
+		GroupByScope:          opts.groupByScope,
+		Sort:                  opts.sort || normalize,
+		OmitURL:               opts.omitURL,
+		OmitEmptyDescriptions: opts.omitEmptyDescriptions,
+	}
+	if _, repo := globalOpts.Repo(); repo != "" && repo != ":repo" {
+		// Otherwise, Terraform resources reference var.repository.
+		formatOpts.Repository = repo
+	}
+	if opts.delimiter != "" {
+		delimiter, err := github.ParseDelimiter(opts.delimiter)
+		if err != nil {
+			return fmt.Errorf(`invalid flag "delimiter": %s`, err)
+		}
+		formatOpts.Delimiter = delimiter
+	}
+	if opts.colorCase != "" {
+		colorCase, err := github.ParseColorCase(opts.colorCase)
+		if err != nil {
+			return fmt.Errorf(`invalid flag "color-case": %s`, err)
+		}
+		formatOpts.ColorCase = colorCase
+	}
+	if opts.fields != "" {
+		fields, err := github.ParseFields(opts.fields)
+		if err != nil {
+			return fmt.Errorf(`invalid flag "fields": %s`, err)
+		}
+		formatOpts.Fields = fields
+	}
+
 	labels, err := opts.client.ListLabels("")
 	if err != nil {
 		return fmt.Errorf("failed to list labels; error: %w", err)
@@ -103,7 +217,7 @@ func export(globalOpts *options.GlobalOptions, opts *exportOptions) error {
 		}
 	}
 
-	if err := labels.Write(github.OutputFormat(opts.format), w); err != nil {
+	if err := labels.WriteWith(github.OutputFormat(opts.format), w, formatOpts); err != nil {
 		return err
 	}
 
@@ -113,3 +227,12 @@ func export(globalOpts *options.GlobalOptions, opts *exportOptions) error {
 
 	return nil
 }
+
+// expandPath replaces "{owner}" and "{repo}" in path unless the repository is not yet resolved.
+func expandPath(path, owner, repo string) string {
+	if path == "-" || strings.HasPrefix(owner, ":") {
+		return path
+	}
+
+	return strings.NewReplacer("{owner}", owner, "{repo}", repo).Replace(path)
+}
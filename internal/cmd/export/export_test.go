@@ -88,6 +88,16 @@ func Test_ExportCmd(t *testing.T) {
 				format: "json",
 			},
 		},
+		{
+			name: "markdown file",
+			args: args{
+				path: "CONTRIBUTING-labels.md",
+			},
+			want: args{
+				path:   "CONTRIBUTING-labels.md",
+				format: "markdown",
+			},
+		},
 		{
 			name: "stream without format",
 			args: args{
@@ -149,9 +159,15 @@ func Test_ExportCmd(t *testing.T) {
 
 func Test_export(t *testing.T) {
 	type args struct {
-		format string
-		stdout string
-		tty    bool
+		format       string
+		delimiter    string
+		groupByScope bool
+		sort         bool
+		colorCase    string
+		omitURL      bool
+		fields       string
+		stdout       string
+		tty          bool
 	}
 
 	tests := []struct {
@@ -223,6 +239,55 @@ func Test_export(t *testing.T) {
 			documentation,0075ca,Improvements or additions to documentation,
 			`),
 		},
+		{
+			name: "tsv",
+			args: args{
+				format: "tsv",
+				stdout: `{
+					"data": {
+						"repository": {
+							"labels": {
+								"nodes": [
+									{
+										"name": "bug",
+										"color": "d73a4a",
+										"description": "Something isn't working"
+									}
+								]
+							}
+						}
+					}
+				}`,
+			},
+			wantW: heredoc.Docf(`name%[1]scolor%[1]sdescription%[1]surl
+			bug%[1]sd73a4a%[1]sSomething isn't working%[1]s
+			`, "\t"),
+		},
+		{
+			name: "csv with delimiter",
+			args: args{
+				format:    "csv",
+				delimiter: ";",
+				stdout: `{
+					"data": {
+						"repository": {
+							"labels": {
+								"nodes": [
+									{
+										"name": "bug",
+										"color": "d73a4a",
+										"description": "Something isn't working"
+									}
+								]
+							}
+						}
+					}
+				}`,
+			},
+			wantW: heredoc.Doc(`name;color;description;url
+			bug;d73a4a;Something isn't working;
+			`),
+		},
 		{
 			name: "json",
 			args: args{
@@ -264,6 +329,126 @@ func Test_export(t *testing.T) {
 			]
 			`),
 		},
+		{
+			name: "markdown grouped by scope",
+			args: args{
+				format:       "markdown",
+				groupByScope: true,
+				stdout: `{
+					"data": {
+						"repository": {
+							"labels": {
+								"nodes": [
+									{
+										"name": "bug",
+										"color": "d73a4a",
+										"description": "Something isn't working"
+									},
+									{
+										"name": "area: docs",
+										"color": "0075ca",
+										"description": "Improvements or additions to documentation"
+									}
+								]
+							}
+						}
+					}
+				}`,
+			},
+			wantW: heredoc.Doc(`### area
+
+			| Color | Name | Description |
+			| --- | --- | --- |
+			| ![#0075ca](https://img.shields.io/badge/-0075ca-0075ca) | area: docs | Improvements or additions to documentation |
+
+			### Other
+
+			| Color | Name | Description |
+			| --- | --- | --- |
+			| ![#d73a4a](https://img.shields.io/badge/-d73a4a-d73a4a) | bug | Something isn't working |
+			`),
+		},
+		{
+			name: "csv normalized",
+			args: args{
+				format:    "csv",
+				sort:      true,
+				colorCase: "upper",
+				omitURL:   true,
+				stdout: `{
+					"data": {
+						"repository": {
+							"labels": {
+								"nodes": [
+									{
+										"name": "documentation",
+										"color": "0075ca",
+										"description": "Improvements or additions to documentation",
+										"url": "https://github.com/heaths/gh-label/labels/documentation"
+									},
+									{
+										"name": "bug",
+										"color": "d73a4a",
+										"description": "Something isn't working",
+										"url": "https://github.com/heaths/gh-label/labels/bug"
+									}
+								]
+							}
+						}
+					}
+				}`,
+			},
+			wantW: heredoc.Doc(`name,color,description
+			bug,D73A4A,Something isn't working
+			documentation,0075CA,Improvements or additions to documentation
+			`),
+		},
+		{
+			name: "invalid color case",
+			args: args{
+				format:    "csv",
+				colorCase: "title",
+			},
+			wantE: true,
+		},
+		{
+			name: "json with fields",
+			args: args{
+				format: "json",
+				fields: "name,description",
+				stdout: `{
+					"data": {
+						"repository": {
+							"labels": {
+								"nodes": [
+									{
+										"name": "bug",
+										"color": "d73a4a",
+										"description": "Something isn't working",
+										"url": "https://github.com/heaths/gh-label/labels/bug"
+									}
+								]
+							}
+						}
+					}
+				}`,
+			},
+			wantW: heredoc.Doc(`[
+			  {
+			    "name": "bug",
+			    "description": "Something isn't working"
+			  }
+			]
+			`),
+		},
+		{
+			name: "invalid fields",
+			args: args{
+				format: "csv",
+				fields: "name,owner",
+			},
+			wantE: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -279,8 +464,14 @@ func Test_export(t *testing.T) {
 
 			rootOpts := &options.GlobalOptions{}
 			opts := &exportOptions{
-				path:   "-",
-				format: tt.args.format,
+				path:         "-",
+				format:       tt.args.format,
+				delimiter:    tt.args.delimiter,
+				groupByScope: tt.args.groupByScope,
+				sort:         tt.args.sort,
+				colorCase:    tt.args.colorCase,
+				omitURL:      tt.args.omitURL,
+				fields:       tt.args.fields,
 
 				client: github.New(mock),
 				io:     io,
@@ -297,3 +488,43 @@ func Test_export(t *testing.T) {
 		})
 	}
 }
+
+func Test_expandPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		owner string
+		repo  string
+		want  string
+	}{
+		{
+			name:  "placeholders",
+			path:  "labels/{owner}-{repo}.csv",
+			owner: "heaths",
+			repo:  "gh-label",
+			want:  "labels/heaths-gh-label.csv",
+		},
+		{
+			name:  "unresolved",
+			path:  "{repo}.csv",
+			owner: ":owner",
+			repo:  ":repo",
+			want:  "{repo}.csv",
+		},
+		{
+			name:  "stdout",
+			path:  "-",
+			owner: "heaths",
+			repo:  "gh-label",
+			want:  "-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandPath(tt.path, tt.owner, tt.repo); got != tt.want {
+				t.Errorf("expandPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
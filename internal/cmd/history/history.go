@@ -0,0 +1,246 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	cliutils "github.com/cli/cli/utils"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+)
+
+type historyOptions struct {
+	name  string
+	actor string
+	since time.Time
+	until time.Time
+	json  bool
+
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+const (
+	applied   = "applied"
+	removed   = "removed"
+	recolored = "recolored"
+)
+
+// entry is a change to a label or where it is applied.
+type entry struct {
+	Time          time.Time `json:"time"`
+	Actor         string    `json:"actor,omitempty"`
+	Action        string    `json:"action"`
+	Label         string    `json:"label"`
+	Color         string    `json:"color"`
+	PreviousColor string    `json:"previousColor,omitempty"`
+	Issue         int       `json:"issue"`
+	PullRequest   bool      `json:"pullRequest,omitempty"`
+}
+
+func HistoryCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &historyOptions{}
+	var since, until string
+	cmd := &cobra.Command{
+		Use:   "history [name]",
+		Short: "Show who applied or removed labels, and when, optionally for only the label [name]",
+		Long: heredoc.Doc(`
+			Show who applied or removed labels on issues and pull requests, and when, optionally for only the label [name].
+
+			History is read from issue events, oldest first. GitHub does not record who created, renamed, recolored,
+			or deleted labels in issue events, but a recolor is shown when a label was applied or removed
+			with a different color than before.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label history
+			$ gh label history bug --actor heaths
+			$ gh label history --since 2026-09-01 --until 2026-09-30 --json
+		`),
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			if since != "" {
+				if opts.since, err = parseTime(since, false); err != nil {
+					return fmt.Errorf(`invalid flag "since": %s`, err)
+				}
+			}
+
+			if until != "" {
+				if opts.until, err = parseTime(until, true); err != nil {
+					return fmt.Errorf(`invalid flag "until": %s`, err)
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.name = args[0]
+			}
+
+			return history(globalOpts, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.actor, "actor", "", "", "Show only changes by the user `login`.")
+	cmd.Flags().StringVarP(&since, "since", "", "", "Show only changes on or after the `date` like 2006-01-02 or 2006-01-02T15:04:05Z.")
+	cmd.Flags().StringVarP(&until, "until", "", "", "Show only changes on or before the `date` like 2006-01-02 or 2006-01-02T15:04:05Z.")
+	cmd.Flags().BoolVarP(&opts.json, "json", "", false, "Write changes as JSON.")
+
+	return cmd
+}
+
+// parseTime parses a date or RFC 3339 time. If end is true, a date is the end of that day.
+func parseTime(s string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date like 2006-01-02 or time like 2006-01-02T15:04:05Z, got %q", s)
+	}
+
+	if end {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	return t, nil
+}
+
+func history(globalOpts *options.GlobalOptions, opts *historyOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	events, err := opts.client.ListLabelEvents()
+	if err != nil {
+		return fmt.Errorf("failed to list issue events; error: %w", err)
+	}
+
+	entries := filter(entries(events), opts)
+
+	if opts.json {
+		if entries == nil {
+			entries = []entry{}
+		}
+
+		enc := json.NewEncoder(opts.io.Out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Showing %d change(s)\n\n", len(entries))
+	}
+
+	printer := cliutils.NewTablePrinter(opts.io)
+	for _, e := range entries {
+		if printer.IsTTY() {
+			printer.AddField(e.Time.Local().Format("2006-01-02 15:04"), nil, nil)
+		} else {
+			printer.AddField(e.Time.Format(time.RFC3339), nil, nil)
+		}
+
+		printer.AddField(e.Actor, nil, nil)
+		printer.AddField(e.Action, nil, nil)
+		printer.AddField(e.Label, nil, nil)
+
+		if e.Action == recolored {
+			printer.AddField(fmt.Sprintf("#%s to #%s before #%d", e.PreviousColor, e.Color, e.Issue), nil, nil)
+		} else {
+			printer.AddField(fmt.Sprintf("#%d", e.Issue), nil, nil)
+		}
+
+		printer.EndRow()
+	}
+
+	return printer.Render()
+}
+
+// entries returns changes in chronological order, inferring recolors from the color of labels when applied or removed.
+func entries(events []github.LabelEvent) []entry {
+	events = append([]github.LabelEvent(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	colors := make(map[string]string)
+	var entries []entry
+
+	for _, event := range events {
+		key := strings.ToLower(event.Label.Name)
+		color := strings.ToLower(event.Label.Color)
+
+		if previous, ok := colors[key]; ok && previous != color {
+			// Who recolored the label and when is unknown.
+			entries = append(entries, entry{
+				Time:          event.CreatedAt,
+				Action:        recolored,
+				Label:         event.Label.Name,
+				Color:         color,
+				PreviousColor: previous,
+				Issue:         event.Issue.Number,
+				PullRequest:   event.Issue.PullRequest,
+			})
+		}
+		colors[key] = color
+
+		action := applied
+		if event.Event == "unlabeled" {
+			action = removed
+		}
+
+		entries = append(entries, entry{
+			Time:        event.CreatedAt,
+			Actor:       event.Actor,
+			Action:      action,
+			Label:       event.Label.Name,
+			Color:       color,
+			Issue:       event.Issue.Number,
+			PullRequest: event.Issue.PullRequest,
+		})
+	}
+
+	return entries
+}
+
+func filter(entries []entry, opts *historyOptions) []entry {
+	var filtered []entry
+	for _, e := range entries {
+		if opts.name != "" && !strings.EqualFold(e.Label, opts.name) {
+			continue
+		}
+
+		if opts.actor != "" && !strings.EqualFold(e.Actor, opts.actor) {
+			continue
+		}
+
+		if !opts.since.IsZero() && e.Time.Before(opts.since) {
+			continue
+		}
+
+		if !opts.until.IsZero() && e.Time.After(opts.until) {
+			continue
+		}
+
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
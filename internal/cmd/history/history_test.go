@@ -0,0 +1,162 @@
+package history
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+)
+
+// Events are listed most recent first.
+var events = heredoc.Doc(`[
+	{
+		"event": "unlabeled",
+		"actor": {"login": "octocat"},
+		"created_at": "2026-10-03T12:00:00Z",
+		"issue": {"number": 1},
+		"label": {"name": "bug", "color": "FF0000"}
+	},
+	{
+		"event": "labeled",
+		"actor": {"login": "heaths"},
+		"created_at": "2026-10-02T12:00:00Z",
+		"issue": {"number": 2, "pull_request": {}},
+		"label": {"name": "wip", "color": "ededed"}
+	},
+	{
+		"event": "labeled",
+		"actor": {"login": "heaths"},
+		"created_at": "2026-10-01T12:00:00Z",
+		"issue": {"number": 1},
+		"label": {"name": "bug", "color": "d73a4a"}
+	}
+]`)
+
+func Test_history(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  historyOptions
+		wantW string
+	}{
+		{
+			name: "all",
+			wantW: heredoc.Docf(`
+				2026-10-01T12:00:00Z%[1]sheaths%[1]sapplied%[1]sbug%[1]s#1
+				2026-10-02T12:00:00Z%[1]sheaths%[1]sapplied%[1]swip%[1]s#2
+				2026-10-03T12:00:00Z%[1]s%[1]srecolored%[1]sbug%[1]s#d73a4a to #ff0000 before #1
+				2026-10-03T12:00:00Z%[1]soctocat%[1]sremoved%[1]sbug%[1]s#1
+			`, "\t"),
+		},
+		{
+			name: "name and actor",
+			opts: historyOptions{
+				name:  "BUG",
+				actor: "heaths",
+			},
+			wantW: heredoc.Docf(`
+				2026-10-01T12:00:00Z%[1]sheaths%[1]sapplied%[1]sbug%[1]s#1
+			`, "\t"),
+		},
+		{
+			name: "json",
+			opts: historyOptions{
+				since: time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC),
+				until: time.Date(2026, 10, 2, 23, 59, 59, 0, time.UTC),
+				json:  true,
+			},
+			wantW: heredoc.Doc(`
+				[
+				  {
+				    "time": "2026-10-02T12:00:00Z",
+				    "actor": "heaths",
+				    "action": "applied",
+				    "label": "wip",
+				    "color": "ededed",
+				    "issue": 2,
+				    "pullRequest": true
+				  }
+				]
+			`),
+		},
+		{
+			name: "json without changes",
+			opts: historyOptions{
+				actor: "nobody",
+				json:  true,
+			},
+			wantW: "[]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+
+			opts := tt.opts
+			opts.client = github.New(&github.Mock{
+				Stdout: *bytes.NewBufferString(events),
+			})
+			opts.io = io
+
+			if err := history(&options.GlobalOptions{}, &opts); err != nil {
+				t.Errorf("history() error = %v", err)
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("history() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func Test_parseTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		end   bool
+		want  time.Time
+		wantE bool
+	}{
+		{
+			name: "date",
+			s:    "2026-10-01",
+			want: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "end of date",
+			s:    "2026-10-01",
+			end:  true,
+			want: time.Date(2026, 10, 1, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name: "time",
+			s:    "2026-10-01T12:30:00Z",
+			end:  true,
+			want: time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "invalid",
+			s:     "10/01/2026",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTime(tt.s, tt.end)
+			if (err != nil) != tt.wantE {
+				t.Errorf("parseTime() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
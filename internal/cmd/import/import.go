@@ -1,27 +1,45 @@
 package importcmd
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/http"
 	"os"
 	"path"
+	"strings"
+	"sync"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/pkg/iostreams"
+	cliutils "github.com/cli/cli/utils"
+	"github.com/heaths/gh-label/internal/git"
 	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/labelset"
 	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 type importOptions struct {
-	path   string
-	format string
+	path      string
+	format    string
+	delimiter string
+	palette   string
+	origins   bool
+	strict    bool
+
+	prune       bool
+	keep        []string
+	concurrency int
 
 	// test
 	client *github.Client
 	fs     fs.FS
+	git    gitService
+	http   *http.Client
 	io     *iostreams.IOStreams
 }
 
@@ -31,46 +49,143 @@ var opts *importOptions
 func ImportCmd(globalOpts *options.GlobalOptions) *cobra.Command {
 	opts = &importOptions{}
 	cmd := &cobra.Command{
-		Use:   "import <path>",
+		Use:   "import [path]",
 		Short: `Import labels into the repository from <path>, or stdin if <path> is "-".`,
+		Long: heredoc.Doc(`
+			Import labels into the repository from <path>, or stdin if <path> is "-".
+
+			The <path> may also be a file in another repository like "OWNER/REPO:path/to/labels.json@ref",
+			a file in the local clone at a branch, tag, or commit like "git:path/to/labels.json@ref",
+			or an http or https URL. The ref is optional and defaults to the default branch or HEAD, respectively.
+			Prefix local paths containing ":" with "./".
+
+			The <path> is optional if "source" is configured in .github/gh-label.yml.
+		`),
 		Example: heredoc.Doc(`
 			$ gh label import ./labels.csv
 			$ gh label import ./labels.json
 			$ gh label import --format csv -
+			$ gh label import ./labels.tf
+			$ gh label import heaths/.github:labels.json@main
+			$ gh label import git:labels.csv@v1.0.0
+			$ gh label import ./labels.yml --origins
+			$ gh label import ./labels.csv --palette spectrum
+			$ gh label import ./labels.csv --strict
+			$ gh label import ./labels.csv --prune --keep "area: *" --concurrency 4
 		`),
-		Args: cobra.ExactArgs(1),
+		Annotations: map[string]string{
+			options.MultiRepoAnnotation: "true",
+		},
+		Args: cobra.MaximumNArgs(1),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			if opts.format != "" {
-				if format, err := github.SupportedOutputFormat(opts.format); err != nil {
+			// Only prune the repository the configuration was discovered in unless --prune is passed.
+			config := globalOpts.Config()
+			if !cmd.Flags().Changed("prune") && globalOpts.Local() {
+				opts.prune = config.Import.Prune
+			}
+			if !cmd.Flags().Changed("keep") && globalOpts.Local() {
+				opts.keep = config.Import.Keep
+			}
+			if !cmd.Flags().Changed("concurrency") {
+				opts.concurrency = config.Import.Concurrency
+			}
+
+			if opts.concurrency < 1 {
+				return fmt.Errorf(`invalid flag "concurrency": must be at least 1, got %d`, opts.concurrency)
+			}
+
+			for _, pattern := range opts.keep {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf(`invalid flag "keep": invalid pattern %q`, pattern)
+				}
+			}
+
+			if opts.format != "" && !labelset.SupportedFormat(opts.format) {
+				if format, err := github.SupportedInputFormat(opts.format); err != nil {
 					return err
 				} else {
 					opts.format = format
 				}
 			}
 
+			if opts.palette != "" {
+				if err := utils.ValidatePalette(opts.palette); err != nil {
+					return fmt.Errorf(`invalid flag "palette": %s`, err)
+				}
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			opts.path = args[0]
-			if opts.path != "-" {
+			if len(args) > 0 {
+				opts.path = args[0]
+			} else if source := globalOpts.Config().SourcePath(globalOpts.Dir()); source != "" {
+				opts.path = source
+			} else {
+				return errors.New(`<path> is required unless "source" is configured`)
+			}
+
+			src, err := parseSource(opts.path)
+			if err != nil {
+				return err
+			}
+
+			if src.kind != stdinSource {
 				if opts.format == "" {
-					opts.format = path.Ext(opts.path)
+					opts.format = src.ext()
 				}
 			} else if opts.format == "" {
 				return fmt.Errorf(`--format is required when <path> is "-"`)
 			}
 
-			if format, err := github.SupportedOutputFormat(opts.format); err != nil {
-				return fmt.Errorf("%q has unsupported format %q, expected %v", opts.path, opts.format, github.OutputFormats())
+			if labelset.SupportedFormat(opts.format) {
+				opts.format = labelset.Format
+			} else if format, err := github.SupportedInputFormat(opts.format); err != nil {
+				return fmt.Errorf("%q has unsupported format %q, expected %v or %s", opts.path, opts.format, github.InputFormats(), labelset.Format)
 			} else {
 				opts.format = format
 			}
 
-			return _import(globalOpts, opts)
+			// Label sets may include csv or tsv files.
+			if opts.delimiter != "" && opts.format != string(github.CSV) && opts.format != string(github.TSV) && opts.format != labelset.Format {
+				return fmt.Errorf(`flag "delimiter" is not supported for format %q`, opts.format)
+			}
+
+			if opts.origins && opts.format != labelset.Format {
+				return fmt.Errorf(`flag "origins" is only supported for format %q`, labelset.Format)
+			}
+
+			var stdin []byte
+			if src.kind == stdinSource && len(globalOpts.Targets()) > 1 {
+				// Read stdin once to import into every repository.
+				if opts.io == nil {
+					opts.io = iostreams.System()
+				}
+				if stdin, err = io.ReadAll(opts.io.In); err != nil {
+					return fmt.Errorf("failed to read labels; error: %w", err)
+				}
+			}
+
+			return globalOpts.ForEachRepo(func(globalOpts *options.GlobalOptions) error {
+				repoOpts := *opts
+				if stdin != nil {
+					ios := *opts.io
+					ios.In = io.NopCloser(bytes.NewReader(stdin))
+					repoOpts.io = &ios
+				}
+				return _import(globalOpts, &repoOpts)
+			})
 		},
 	}
 
-	cmd.Flags().StringVarP(&opts.format, "format", "", "", fmt.Sprintf("Format of the input to parse. One of %v. The default is the file extension.", github.OutputFormats()))
+	cmd.Flags().StringVarP(&opts.format, "format", "", "", fmt.Sprintf("Format of the input to parse. One of %v or %s. The default is the file extension.", github.InputFormats(), labelset.Format))
+	cmd.Flags().StringVarP(&opts.delimiter, "delimiter", "", "", `Field delimiter for csv or tsv formats, like ";" or "\t". The default is "," for csv and a tab for tsv.`)
+	cmd.Flags().StringVarP(&opts.palette, "palette", "p", "", fmt.Sprintf("Assign distinct colors from the palette to labels without a color. One of %v.", utils.PaletteNames()))
+	cmd.Flags().BoolVarP(&opts.origins, "origins", "", false, "Print where each label in a label set was defined or overridden without importing them.")
+	cmd.Flags().BoolVarP(&opts.strict, "strict", "", false, "Import no labels if any problems are found.")
+	cmd.Flags().BoolVarP(&opts.prune, "prune", "", false, "Delete labels from the repository that were not imported, if all labels were imported.")
+	cmd.Flags().StringSliceVarP(&opts.keep, "keep", "", nil, `Names or patterns like "area: *" of labels never to prune.`)
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 1, "Number of labels to import at the same time.")
 
 	return cmd
 }
@@ -81,6 +196,7 @@ func _import(globalOpts *options.GlobalOptions, opts *importOptions) error {
 		cli := &github.Cli{
 			Owner: owner,
 			Repo:  repo,
+			Host:  globalOpts.Host(),
 		}
 		opts.client = github.New(cli)
 	}
@@ -93,46 +209,115 @@ func _import(globalOpts *options.GlobalOptions, opts *importOptions) error {
 		opts.fs = os.DirFS(pwd)
 	}
 
+	if opts.git == nil {
+		opts.git = &git.Cli{}
+	}
+
+	if opts.http == nil {
+		opts.http = http.DefaultClient
+	}
+
 	if opts.io == nil {
 		opts.io = iostreams.System()
 	}
 
-	var r io.Reader
-	if opts.path == "-" {
-		r = opts.io.In
-	} else {
-		if file, err := opts.fs.Open(opts.path); err != nil {
-			return fmt.Errorf("failed to open file %q; error: %w", opts.path, err)
-		} else {
-			r = file
-			defer file.Close()
+	formatOpts := github.FormatOptions{}
+	if opts.delimiter != "" {
+		delimiter, err := github.ParseDelimiter(opts.delimiter)
+		if err != nil {
+			return fmt.Errorf(`invalid flag "delimiter": %s`, err)
 		}
+		formatOpts.Delimiter = delimiter
 	}
 
-	labels, err := github.ReadLabels(github.OutputFormat(opts.format), r)
+	src, err := parseSource(opts.path)
 	if err != nil {
-		return fmt.Errorf("failed to read labels; error: %w", err)
+		return err
+	}
+
+	r, err := src.open(opts)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var labels github.Labels
+	var problems []github.Problem
+	if opts.format == labelset.Format {
+		if labels, err = resolve(opts, src, r, formatOpts); labels == nil {
+			return err
+		}
+	} else {
+		labels, problems, err = github.ValidateLabels(github.OutputFormat(opts.format), r, formatOpts)
+		if err != nil {
+			return fmt.Errorf("failed to read labels; error: %w", err)
+		}
+
+		fatal := false
+		for _, problem := range problems {
+			fmt.Fprintf(opts.io.ErrOut, "%s:%s\n", opts.path, problem)
+			fatal = fatal || problem.Fatal()
+		}
+
+		// Labels may be missing after a fatal problem like a syntax error.
+		if fatal || len(problems) > 0 && opts.strict {
+			return fmt.Errorf("found %d problem(s) in %q", len(problems), opts.path)
+		}
+	}
+
+	// Skip labels with problems, which count as failures.
+	valid := github.ValidLabels(labels, problems)
+	skipped := len(labels) - len(valid)
+
+	if opts.palette != "" {
+		if err := assignColors(labels, opts.palette); err != nil {
+			return err
+		}
 	}
 
 	if opts.io.IsStdoutTTY() {
 		fmt.Fprintf(opts.io.Out, "Importing %d label(s) from %q\n\n", len(labels), opts.path)
 	}
+	labels = valid
 
 	// TODO: Write progress bar if TTY.
 
+	concurrency := opts.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
 	successes := 0
-	failures := 0
+	failures := skipped
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
 
 	for _, label := range labels {
-		if _, err := opts.client.CreateOrUpdateLabel(label); err != nil {
-			failures++
-			fmt.Fprintf(opts.io.ErrOut, "Failed to import label %q\n", label.Name)
-			continue
-		}
+		label := label
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-		successes++
+			ok := importLabel(opts, label, &mu)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				successes++
+			} else {
+				failures++
+			}
+		}()
 	}
 
+	wg.Wait()
+
 	if opts.io.IsStdoutTTY() {
 		if failures > 0 {
 			fmt.Fprintf(opts.io.ErrOut, "\n")
@@ -145,5 +330,181 @@ func _import(globalOpts *options.GlobalOptions, opts *importOptions) error {
 		return errors.New("failed to import all labels")
 	}
 
+	if opts.prune {
+		if failures > 0 || len(problems) > 0 {
+			fmt.Fprintln(opts.io.ErrOut, "Skipped pruning labels because some labels failed to import")
+			return nil
+		}
+
+		return prune(opts, labels)
+	}
+
+	return nil
+}
+
+// importLabel creates or updates label and writes any messages while holding mu.
+func importLabel(opts *importOptions, label github.Label, mu *sync.Mutex) bool {
+	var resolved string
+	if label.Color != "" {
+		color, err := utils.ValidateColor(label.Color)
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Fprintf(opts.io.ErrOut, "Failed to import label %q: %s\n", label.Name, err)
+			return false
+		}
+
+		if color != strings.TrimPrefix(label.Color, "#") {
+			resolved = label.Color
+		}
+		label.Color = color
+	}
+
+	_, err := opts.client.CreateOrUpdateLabel(label)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if resolved != "" && opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Resolved color '%s' to #%s for label '%s'\n", resolved, label.Color, label.Name)
+	}
+
+	if err != nil {
+		fmt.Fprintf(opts.io.ErrOut, "Failed to import label %q\n", label.Name)
+		return false
+	}
+
+	return true
+}
+
+// prune deletes labels from the repository that are not in labels and do not match opts.keep.
+func prune(opts *importOptions, labels github.Labels) error {
+	imported := make(map[string]bool)
+	for _, label := range labels {
+		imported[strings.ToLower(label.Name)] = true
+	}
+
+	existing, err := opts.client.ListLabels("")
+	if err != nil {
+		return fmt.Errorf("failed to list labels to prune; error: %w", err)
+	}
+
+	pruned := 0
+	for _, label := range existing {
+		if imported[strings.ToLower(label.Name)] || keep(opts.keep, label.Name) {
+			continue
+		}
+
+		if err := opts.client.DeleteLabel(label.Name); err != nil {
+			return fmt.Errorf("failed to prune label %q; error: %w", label.Name, err)
+		}
+
+		pruned++
+		if opts.io.IsStdoutTTY() {
+			fmt.Fprintf(opts.io.Out, "Deleted label '%s'\n", label.Name)
+		}
+	}
+
+	if opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Pruned %d label(s)\n", pruned)
+	}
+
+	return nil
+}
+
+// keep returns whether name matches any of patterns without regard to case.
+func keep(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve resolves a label set and its extended or included files relative to the source.
+// It returns nil labels if they should not be imported, along with any error.
+func resolve(opts *importOptions, src source, r io.Reader, formatOpts github.FormatOptions) (github.Labels, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read labels; error: %w", err)
+	}
+
+	fsys, name := src.fs(opts)
+	resolver := &labelset.Resolver{
+		FS:            fsys,
+		FormatOptions: formatOpts,
+		Data: func() (*labelset.TemplateData, error) {
+			repo, err := opts.client.GetRepository()
+			if err != nil {
+				return nil, err
+			}
+
+			data := &labelset.TemplateData{
+				Owner:      repo.Owner,
+				Repo:       repo.Name,
+				Language:   repo.Language,
+				Visibility: repo.Visibility,
+				Topics:     repo.Topics,
+			}
+
+			// Label sets from another repository or URL could otherwise publish secrets in label descriptions.
+			if src.local() {
+				data.Env = labelset.Environ()
+			}
+
+			return data, nil
+		},
+	}
+
+	set, err := resolver.ResolveData(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve labels; error: %w", err)
+	}
+
+	if problems := set.Validate(); len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Fprintln(opts.io.ErrOut, problem)
+		}
+
+		return nil, fmt.Errorf("found %d problem(s) in %q", len(problems), opts.path)
+	}
+
+	if opts.origins {
+		printer := cliutils.NewTablePrinter(opts.io)
+		for _, label := range set {
+			origins := make([]string, len(label.Origins))
+			for i, origin := range label.Origins {
+				origins[i] = origin.String()
+			}
+
+			printer.AddField(label.Name, nil, nil)
+			printer.AddField(strings.Join(origins, ", "), nil, nil)
+			printer.EndRow()
+		}
+
+		return nil, printer.Render()
+	}
+
+	return set.Labels(), nil
+}
+
+func assignColors(labels github.Labels, palette string) error {
+	var uncolored []int
+	for i, label := range labels {
+		if label.Color == "" {
+			uncolored = append(uncolored, i)
+		}
+	}
+
+	colors, err := utils.PaletteColors(palette, len(uncolored))
+	if err != nil {
+		return err
+	}
+
+	for i, j := range uncolored {
+		labels[j].Color = colors[i]
+	}
+
 	return nil
 }
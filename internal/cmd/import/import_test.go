@@ -1,10 +1,17 @@
 package importcmd
 
-// cSpell:ignore fstest
+// cSpell:ignore fstest notacolor
 
 import (
 	"bytes"
 	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"testing/fstest"
 
@@ -12,6 +19,7 @@ import (
 	"github.com/cli/cli/pkg/iostreams"
 	"github.com/heaths/gh-label/internal/github"
 	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -29,6 +37,15 @@ var (
 		]`))
 
 	jsonLabel = bytes.Trim(jsonData, "[]")
+
+	terraformData = []byte(heredoc.Doc(`
+		resource "github_issue_label" "bug" {
+		  repository  = var.repository
+		  name        = "bug"
+		  color       = "d73a4a"
+		  description = "Something isn't working"
+		}
+		`))
 )
 
 func Test_ImportCmd(t *testing.T) {
@@ -114,6 +131,39 @@ func Test_ImportCmd(t *testing.T) {
 				format: "json",
 			},
 		},
+		{
+			name: "terraform file",
+			args: args{
+				path: "labels.tf",
+				data: terraformData,
+			},
+			want: args{
+				path:   "labels.tf",
+				format: "terraform",
+			},
+		},
+		{
+			name: "label set file",
+			args: args{
+				path: "labels.yml",
+				data: []byte("labels:\n  - name: bug\n    color: d73a4a\n"),
+			},
+			want: args{
+				path:   "labels.yml",
+				format: "yaml",
+			},
+		},
+		{
+			name: "relative csv file",
+			args: args{
+				path: "./labels.csv",
+				data: csvData,
+			},
+			want: args{
+				path:   "./labels.csv",
+				format: "csv",
+			},
+		},
 		{
 			name: "stream without format",
 			args: args{
@@ -156,7 +206,7 @@ func Test_ImportCmd(t *testing.T) {
 			opts.client = github.New(mock)
 
 			fs := fstest.MapFS{}
-			fs[tt.args.path] = &fstest.MapFile{
+			fs[path.Clean(tt.args.path)] = &fstest.MapFile{
 				Data: tt.args.data,
 			}
 			opts.fs = fs
@@ -187,17 +237,20 @@ func Test_ImportCmd(t *testing.T) {
 
 func Test_import(t *testing.T) {
 	type args struct {
-		format string
-		stdin  []byte
-		tty    bool
-		err    error
+		format    string
+		delimiter string
+		stdin     []byte
+		tty       bool
+		strict    bool
+		err       error
 	}
 
 	tests := []struct {
-		name  string
-		args  args
-		wantW string
-		wantE bool
+		name         string
+		args         args
+		wantImported []string
+		wantW        string
+		wantE        bool
 	}{
 		{
 			name: "csv",
@@ -205,6 +258,7 @@ func Test_import(t *testing.T) {
 				format: "csv",
 				stdin:  csvData,
 			},
+			wantImported: []string{"bug"},
 		},
 		{
 			name: "csv (tty)",
@@ -213,17 +267,52 @@ func Test_import(t *testing.T) {
 				stdin:  csvData,
 				tty:    true,
 			},
+			wantImported: []string{"bug"},
+			wantW: heredoc.Doc(`Importing 1 label(s) from "-"
+
+			Successfully imported 1, failed to import 0 label(s)
+			`),
+		},
+		{
+			name: "csv with delimiter (tty)",
+			args: args{
+				format:    "csv",
+				delimiter: ";",
+				stdin: []byte(heredoc.Doc(`description;name;color
+				Something isn't working;bug;d73a4a
+				`)),
+				tty: true,
+			},
+			wantImported: []string{"bug"},
 			wantW: heredoc.Doc(`Importing 1 label(s) from "-"
 
 			Successfully imported 1, failed to import 0 label(s)
 			`),
 		},
+		{
+			name: "tsv",
+			args: args{
+				format: "tsv",
+				stdin:  []byte("name\tcolor\nbug\td73a4a\n"),
+			},
+			wantImported: []string{"bug"},
+		},
+		{
+			name: "invalid delimiter",
+			args: args{
+				format:    "csv",
+				delimiter: ";;",
+				stdin:     csvData,
+			},
+			wantE: true,
+		},
 		{
 			name: "json",
 			args: args{
 				format: "json",
 				stdin:  jsonData,
 			},
+			wantImported: []string{"bug"},
 		},
 		{
 			name: "json (tty)",
@@ -232,11 +321,87 @@ func Test_import(t *testing.T) {
 				stdin:  jsonData,
 				tty:    true,
 			},
+			wantImported: []string{"bug"},
 			wantW: heredoc.Doc(`Importing 1 label(s) from "-"
 
 			Successfully imported 1, failed to import 0 label(s)
 			`),
 		},
+		{
+			name: "resolved colors (tty)",
+			args: args{
+				format: "csv",
+				stdin: []byte(heredoc.Doc(`name,color,description,url
+				bug,tomato,Something isn't working,
+				documentation,#0075ca,Improvements or additions to documentation,
+				`)),
+				tty: true,
+			},
+			wantImported: []string{"bug", "documentation"},
+			wantW: heredoc.Doc(`Importing 2 label(s) from "-"
+
+			Resolved color 'tomato' to #FF6347 for label 'bug'
+			Successfully imported 2, failed to import 0 label(s)
+			`),
+		},
+		{
+			name: "invalid color (tty)",
+			args: args{
+				format: "csv",
+				stdin: []byte(heredoc.Doc(`name,color,description,url
+				bug,notacolor,Something isn't working,
+				documentation,0075ca,Improvements or additions to documentation,
+				`)),
+				tty: true,
+			},
+			wantImported: []string{"documentation"},
+			wantW: heredoc.Doc(`Importing 2 label(s) from "-"
+
+			Successfully imported 1, failed to import 1 label(s)
+			`),
+		},
+		{
+			name: "invalid color (strict)",
+			args: args{
+				format: "csv",
+				stdin: []byte(heredoc.Doc(`name,color,description,url
+				bug,notacolor,Something isn't working,
+				documentation,0075ca,Improvements or additions to documentation,
+				`)),
+				strict: true,
+			},
+			wantE: true,
+		},
+		{
+			name: "problems",
+			args: args{
+				format: "csv",
+				stdin: []byte(heredoc.Doc(`name,color,description,url
+				bug,d73a4a,Something isn't working,
+				,0075ca,No name,
+				BUG,d73a4a,Duplicate,
+				enhancement,notacolor,New feature or request,
+				question,d876e3,` + strings.Repeat("?", 101) + `,
+				`)),
+				tty: true,
+			},
+			wantImported: []string{"bug"},
+			wantW: heredoc.Doc(`Importing 5 label(s) from "-"
+
+			Successfully imported 1, failed to import 4 label(s)
+			`),
+		},
+		{
+			name: "syntax error",
+			args: args{
+				format: "csv",
+				stdin: []byte(heredoc.Doc(`name,color,description,url
+				bug,d73a4a,Something isn't working,
+				"documentation,0075ca,Improvements or additions to documentation,
+				`)),
+			},
+			wantE: true,
+		},
 		{
 			name: "all failed",
 			args: args{
@@ -244,7 +409,8 @@ func Test_import(t *testing.T) {
 				stdin:  jsonData,
 				err:    errors.New("failed"),
 			},
-			wantE: true,
+			wantImported: []string{"bug"},
+			wantE:        true,
 		},
 	}
 
@@ -256,15 +422,19 @@ func Test_import(t *testing.T) {
 			stdin.Write(tt.args.stdin)
 
 			// Set up gh output.
-			mock := &github.Mock{
-				Stdout: *bytes.NewBuffer(jsonLabel),
-				Err:    tt.args.err,
+			mock := &pruneMock{
+				Mock: github.Mock{
+					Stdout: *bytes.NewBuffer(jsonLabel),
+					Err:    tt.args.err,
+				},
 			}
 
 			rootOpts := &options.GlobalOptions{}
 			opts := &importOptions{
-				path:   "-",
-				format: tt.args.format,
+				path:      "-",
+				format:    tt.args.format,
+				delimiter: tt.args.delimiter,
+				strict:    tt.args.strict,
 
 				client: github.New(mock),
 				io:     io,
@@ -275,9 +445,387 @@ func Test_import(t *testing.T) {
 				return
 			}
 
+			sort.Strings(mock.imported)
+			if !reflect.DeepEqual(mock.imported, tt.wantImported) {
+				t.Errorf("_import() imported %q, want %q", mock.imported, tt.wantImported)
+			}
+
 			if gotW := stdout.String(); gotW != tt.wantW {
 				t.Errorf("_import() = %q, want %q", gotW, tt.wantW)
 			}
 		})
 	}
 }
+
+func Test_import_labelSet(t *testing.T) {
+	fs := fstest.MapFS{
+		"templates/base.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				labels:
+				  - name: bug
+				    color: d73a4a
+				  - name: wontfix
+				    color: ffffff
+			`)),
+		},
+		"labels.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				extends: templates/base.yml
+				labels:
+				  - name: bug
+				    description: Something isn't working
+				remove: [wontfix]
+			`)),
+		},
+		"template.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				labels:
+				  - name: bug
+				    color: d73a4a
+				    description: Something isn't working in {{ .Owner }}/{{ .Repo }}
+				  - name: go
+				    color: 00add8
+				    if: {{ eq .Language "Go" }}
+			`)),
+		},
+		"invalid.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				extends: templates/base.yml
+				labels:
+				  - name: bug
+				    color: notacolor
+			`)),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		origins bool
+		wantW   string
+		wantE   string
+	}{
+		{
+			name:  "import",
+			path:  "labels.yml",
+			wantW: "Importing 1 label(s) from \"labels.yml\"\n\nSuccessfully imported 1, failed to import 0 label(s)\n",
+		},
+		{
+			name:    "origins",
+			path:    "labels.yml",
+			origins: true,
+			wantW:   "bug  templates/base.yml:2, labels.yml:3\n",
+		},
+		{
+			name: "stdin",
+			path: "-",
+			stdin: heredoc.Doc(`
+				extends: templates/base.yml
+				labels:
+				  - name: bug
+				    description: Something isn't working
+			`),
+			origins: true,
+			wantW:   "bug      templates/base.yml:2, -:3\nwontfix  templates/base.yml:4\n",
+		},
+		{
+			name:    "template",
+			path:    "template.yml",
+			origins: true,
+			wantW:   "bug  template.yml:2\n",
+		},
+		{
+			name:  "invalid",
+			path:  "invalid.yml",
+			wantE: "invalid.yml:3: color: invalid color \"notacolor\" for label \"bug\": colors must include 6 hexadecimal digits for RGB with optional \"#\" prefix, 3 hexadecimal digits with \"#\" prefix, a color name, rgb(), or hsl()\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, stdout, stderr := iostreams.Test()
+			io.SetStdoutTTY(true)
+			stdin.WriteString(tt.stdin)
+
+			mock := &github.Mock{
+				Stdout: *bytes.NewBuffer(jsonLabel),
+			}
+
+			opts := &importOptions{
+				path:    tt.path,
+				format:  "yaml",
+				origins: tt.origins,
+
+				client: github.New(mock),
+				fs:     fs,
+				io:     io,
+			}
+
+			if err := _import(&options.GlobalOptions{}, opts); (err != nil) != (tt.wantE != "") {
+				t.Errorf("_import() error = %v, wantE %v", err, tt.wantE != "")
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("_import() = %q, want %q", gotW, tt.wantW)
+			}
+
+			if gotE := stderr.String(); gotE != tt.wantE {
+				t.Errorf("_import() stderr = %q, want %q", gotE, tt.wantE)
+			}
+		})
+	}
+}
+
+func Test_import_prune(t *testing.T) {
+	existing := `{"data":{"repository":{"labels":{"nodes":[` +
+		`{"name":"Bug","color":"d73a4a"},` +
+		`{"name":"wontfix","color":"ffffff"},` +
+		`{"name":"area: cli","color":"0075ca"}` +
+		`]}}}}`
+
+	tests := []struct {
+		name         string
+		stdin        string
+		keep         []string
+		wantImported []string
+		wantDeleted  []string
+		wantW        string
+		wantE        bool
+	}{
+		{
+			name:         "prune",
+			wantImported: []string{"bug"},
+			wantDeleted:  []string{"wontfix", "area: cli"},
+			wantW: heredoc.Doc(`Importing 1 label(s) from "-"
+
+			Successfully imported 1, failed to import 0 label(s)
+			Deleted label 'wontfix'
+			Deleted label 'area: cli'
+			Pruned 2 label(s)
+			`),
+		},
+		{
+			name:         "keep",
+			keep:         []string{"AREA: *"},
+			wantImported: []string{"bug"},
+			wantDeleted:  []string{"wontfix"},
+			wantW: heredoc.Doc(`Importing 1 label(s) from "-"
+
+			Successfully imported 1, failed to import 0 label(s)
+			Deleted label 'wontfix'
+			Pruned 1 label(s)
+			`),
+		},
+		{
+			name: "syntax error",
+			stdin: heredoc.Doc(`name,color,description
+			bug,d73a4a,x
+			wontfix,"ff"ff,y
+			enhancement,0075ca,z
+			`),
+			wantE: true,
+		},
+		{
+			name: "invalid labels",
+			stdin: heredoc.Doc(`name,color,description
+			bug,d73a4a,x
+			,ffffff,y
+			wontfix,notacolor,z
+			BUG,d73a4a,duplicate
+			`),
+			wantImported: []string{"bug"},
+			wantW: heredoc.Doc(`Importing 4 label(s) from "-"
+
+			Successfully imported 1, failed to import 3 label(s)
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(true)
+			if tt.stdin != "" {
+				stdin.WriteString(tt.stdin)
+			} else {
+				stdin.Write(csvData)
+			}
+
+			mock := &pruneMock{
+				Mock: github.Mock{
+					Stdout: *bytes.NewBuffer(jsonLabel),
+				},
+				labels: *bytes.NewBufferString(existing),
+			}
+
+			opts := &importOptions{
+				path:        "-",
+				format:      "csv",
+				prune:       true,
+				keep:        tt.keep,
+				concurrency: 2,
+
+				client: github.New(mock),
+				io:     io,
+			}
+
+			if err := _import(&options.GlobalOptions{}, opts); (err != nil) != tt.wantE {
+				t.Errorf("_import() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(mock.imported, tt.wantImported) {
+				t.Errorf("_import() imported %q, want %q", mock.imported, tt.wantImported)
+			}
+
+			if !reflect.DeepEqual(mock.deleted, tt.wantDeleted) {
+				t.Errorf("_import() deleted %q, want %q", mock.deleted, tt.wantDeleted)
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("_import() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func Test_ImportCmd_config(t *testing.T) {
+	existing := `{"data":{"repository":{"labels":{"nodes":[` +
+		`{"name":"bug","color":"d73a4a"},` +
+		`{"name":"wontfix","color":"ffffff"}` +
+		`]}}}}`
+
+	tests := []struct {
+		name        string
+		labels      string
+		wantDeleted []string
+		wantE       bool
+	}{
+		{
+			name:        "prune",
+			labels:      "name,color\nbug,d73a4a\n",
+			wantDeleted: []string{"wontfix"},
+		},
+		{
+			name:   "syntax error",
+			labels: "name,color\nbug,d73a4a\nwontfix,\"ff\"ff\n",
+			wantE:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Import the configured source from a subdirectory of the repository.
+			root := t.TempDir()
+			sub := filepath.Join(root, "src")
+			for _, dir := range []string{".git", ".github", "src"} {
+				if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			config := "source: labels.csv\nimport:\n  prune: true\n"
+			if err := os.WriteFile(filepath.Join(root, ".github", "gh-label.yml"), []byte(config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(root, "labels.csv"), []byte(tt.labels), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			wd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chdir(sub); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(wd)
+
+			rootCmd := &cobra.Command{Use: "label"}
+			globalOpts := options.New(rootCmd)
+			rootCmd.AddCommand(ImportCmd(globalOpts))
+			rootCmd.SetArgs([]string{"import"})
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			mock := &pruneMock{
+				Mock: github.Mock{
+					Stdout: *bytes.NewBuffer(jsonLabel),
+				},
+				labels: *bytes.NewBufferString(existing),
+			}
+			opts.client = github.New(mock)
+
+			io, _, _, _ := iostreams.Test()
+			opts.io = io
+
+			if err := rootCmd.Execute(); (err != nil) != tt.wantE {
+				t.Errorf("Execute() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if opts.path != "../labels.csv" {
+				t.Errorf("path = %q, want %q", opts.path, "../labels.csv")
+			}
+
+			if !reflect.DeepEqual(mock.deleted, tt.wantDeleted) {
+				t.Errorf("deleted %q, want %q", mock.deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+// pruneMock lists existing labels and records imported and deleted labels.
+type pruneMock struct {
+	github.Mock
+
+	labels   bytes.Buffer
+	mu       sync.Mutex
+	imported []string
+	deleted  []string
+}
+
+func (m *pruneMock) CreateLabel(label github.Label) (bytes.Buffer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.imported = append(m.imported, label.Name)
+	return m.Stdout, m.Err
+}
+
+func (m *pruneMock) ListLabels(substr string) (bytes.Buffer, error) {
+	return m.labels, nil
+}
+
+func (m *pruneMock) DeleteLabel(name string) error {
+	m.deleted = append(m.deleted, name)
+	return nil
+}
+
+func Test_assignColors(t *testing.T) {
+	labels := github.Labels{
+		{Name: "bug"},
+		{Name: "documentation", Color: "0075ca"},
+		{Name: "enhancement"},
+	}
+
+	if err := assignColors(labels, "okabe-ito"); err != nil {
+		t.Errorf("assignColors() error = %v", err)
+		return
+	}
+
+	want := github.Labels{
+		{Name: "bug", Color: "e69f00"},
+		{Name: "documentation", Color: "0075ca"},
+		{Name: "enhancement", Color: "56b4e9"},
+	}
+
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("assignColors() = %v, want %v", labels, want)
+	}
+
+	if err := assignColors(labels, "unknown"); err == nil {
+		t.Error("assignColors() expected error")
+	}
+}
@@ -0,0 +1,232 @@
+package importcmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-label/internal/utils"
+)
+
+type sourceKind int
+
+const (
+	fileSource sourceKind = iota
+	stdinSource
+	repoSource
+	gitSource
+	urlSource
+)
+
+// source describes where to import labels from.
+type source struct {
+	kind sourceKind
+
+	// path is the file path, or the URL for urlSource.
+	path string
+
+	// owner and repo are set for repoSource.
+	owner string
+	repo  string
+
+	// ref is an optional branch, tag, or commit for repoSource and gitSource.
+	ref string
+}
+
+// repoSourcePattern matches OWNER/REPO:path[@ref].
+var repoSourcePattern = regexp.MustCompile(`^([\w.-]+)/([\w.-]+):([^@]+)(?:@(.+))?$`)
+
+// parseSource parses "-" for stdin, "git:path[@ref]" for a file in the local clone,
+// "OWNER/REPO:path[@ref]" for a file in another repository, an http or https URL, or a local file path.
+func parseSource(s string) (source, error) {
+	if s == "-" {
+		return source{kind: stdinSource, path: s}, nil
+	}
+
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return source{}, fmt.Errorf("invalid URL %q; error: %w", s, err)
+		}
+		return source{kind: urlSource, path: u.String()}, nil
+	}
+
+	if strings.HasPrefix(s, "git:") {
+		file, ref := splitRef(strings.TrimPrefix(s, "git:"))
+		if file == "" {
+			return source{}, fmt.Errorf(`expected "git:path[@ref]", got %q`, s)
+		}
+		return source{kind: gitSource, path: file, ref: ref}, nil
+	}
+
+	if m := repoSourcePattern.FindStringSubmatch(s); m != nil {
+		return source{kind: repoSource, owner: m[1], repo: m[2], path: m[3], ref: m[4]}, nil
+	}
+
+	return source{kind: fileSource, path: s}, nil
+}
+
+func splitRef(s string) (file, ref string) {
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// local returns whether the source is a local file, stdin, or a file in the local clone.
+func (src source) local() bool {
+	return src.kind == fileSource || src.kind == stdinSource || src.kind == gitSource
+}
+
+// ext returns the file extension of the source path used to detect its format.
+func (src source) ext() string {
+	if src.kind == urlSource {
+		if u, err := url.Parse(src.path); err == nil {
+			return path.Ext(u.Path)
+		}
+	}
+	return path.Ext(src.path)
+}
+
+// gitService reads files from the local clone.
+type gitService interface {
+	Show(rev, path string) ([]byte, error)
+}
+
+// open opens the source for reading.
+func (src source) open(opts *importOptions) (io.ReadCloser, error) {
+	switch src.kind {
+	case stdinSource:
+		return io.NopCloser(opts.io.In), nil
+
+	case repoSource:
+		data, err := opts.client.GetContents(src.owner, src.repo, src.path, src.ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %q from %s/%s; error: %w", src.path, src.owner, src.repo, err)
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+
+	case gitSource:
+		data, err := opts.git.Show(src.ref, src.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q from git; error: %w", src.path, err)
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+
+	case urlSource:
+		resp, err := opts.http.Get(src.path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %q; error: %w", src.path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to get %q; status: %s", src.path, resp.Status)
+		}
+		return resp.Body, nil
+	}
+
+	file, err := utils.OpenFile(opts.fs, src.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q; error: %w", src.path, err)
+	}
+	return file, nil
+}
+
+// fs returns a file system containing files relative to the source, like those a label set extends or includes,
+// and the name of the source within it.
+func (src source) fs(opts *importOptions) (fs.FS, string) {
+	sibling := func(name string) source {
+		s := src
+		s.path = name
+		return s
+	}
+
+	switch src.kind {
+	case stdinSource:
+		return opts.fs, src.path
+
+	case repoSource, gitSource:
+		return &sourceFS{opts, sibling}, path.Clean(src.path)
+
+	case urlSource:
+		u, err := url.Parse(src.path)
+		if err != nil {
+			break
+		}
+
+		name := path.Base(u.Path)
+		u.Path = path.Dir(u.Path) + "/"
+		u.RawQuery = ""
+		return &sourceFS{opts, func(name string) source {
+			return sibling(u.ResolveReference(&url.URL{Path: name}).String())
+		}}, name
+	}
+
+	return utils.FileFS(opts.fs, src.path)
+}
+
+// sourceFS is a read-only file system of files opened from sources.
+type sourceFS struct {
+	opts   *importOptions
+	source func(name string) source
+}
+
+func (sfs *sourceFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	r, err := sfs.source(name).open(sfs.opts)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+
+	return &sourceFile{bytes.NewReader(data), name}, nil
+}
+
+// sourceFile is a file read from a source.
+type sourceFile struct {
+	*bytes.Reader
+	name string
+}
+
+func (f *sourceFile) Stat() (fs.FileInfo, error) {
+	return f, nil
+}
+
+func (f *sourceFile) Close() error {
+	return nil
+}
+
+func (f *sourceFile) Name() string {
+	return path.Base(f.name)
+}
+
+func (f *sourceFile) Mode() fs.FileMode {
+	return 0444
+}
+
+func (f *sourceFile) ModTime() time.Time {
+	return time.Time{}
+}
+
+func (f *sourceFile) IsDir() bool {
+	return false
+}
+
+func (f *sourceFile) Sys() interface{} {
+	return nil
+}
@@ -0,0 +1,312 @@
+package importcmd
+
+// cSpell:ignore fstest
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func Test_parseSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		arg     string
+		want    source
+		wantExt string
+		local   bool
+		wantE   bool
+	}{
+		{
+			name:    "file",
+			arg:     "labels.csv",
+			want:    source{kind: fileSource, path: "labels.csv"},
+			local:   true,
+			wantExt: ".csv",
+		},
+		{
+			name:    "relative file with colon",
+			arg:     "./heaths/gh-label:labels.csv",
+			want:    source{kind: fileSource, path: "./heaths/gh-label:labels.csv"},
+			local:   true,
+			wantExt: ".csv",
+		},
+		{
+			name:  "stdin",
+			arg:   "-",
+			want:  source{kind: stdinSource, path: "-"},
+			local: true,
+		},
+		{
+			name:    "repo",
+			arg:     "heaths/.github:path/to/labels.json",
+			want:    source{kind: repoSource, owner: "heaths", repo: ".github", path: "path/to/labels.json"},
+			wantExt: ".json",
+		},
+		{
+			name:    "repo with ref",
+			arg:     "heaths/.github:labels.json@feature/labels",
+			want:    source{kind: repoSource, owner: "heaths", repo: ".github", path: "labels.json", ref: "feature/labels"},
+			wantExt: ".json",
+		},
+		{
+			name:    "git",
+			arg:     "git:labels.tsv",
+			want:    source{kind: gitSource, path: "labels.tsv"},
+			local:   true,
+			wantExt: ".tsv",
+		},
+		{
+			name:    "git with ref",
+			arg:     "git:.github/labels.csv@v1.0.0",
+			want:    source{kind: gitSource, path: ".github/labels.csv", ref: "v1.0.0"},
+			local:   true,
+			wantExt: ".csv",
+		},
+		{
+			name:  "git without path",
+			arg:   "git:@main",
+			wantE: true,
+		},
+		{
+			name:    "url",
+			arg:     "https://example.com/labels.json?token=abc",
+			want:    source{kind: urlSource, path: "https://example.com/labels.json?token=abc"},
+			wantExt: ".json",
+		},
+		{
+			name:  "invalid url",
+			arg:   "https://example.com/%zz",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSource(tt.arg)
+			if (err != nil) != tt.wantE {
+				t.Errorf("parseSource() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSource() = %+v, want %+v", got, tt.want)
+			}
+
+			if ext := got.ext(); ext != tt.wantExt {
+				t.Errorf("source.ext() = %q, want %q", ext, tt.wantExt)
+			}
+
+			if local := got.local(); !tt.wantE && local != tt.local {
+				t.Errorf("source.local() = %v, want %v", local, tt.local)
+			}
+		})
+	}
+}
+
+type mockGit struct {
+	files map[string]string
+}
+
+func (g *mockGit) Show(rev, path string) ([]byte, error) {
+	if data, ok := g.files[rev+":"+path]; ok {
+		return []byte(data), nil
+	}
+	return nil, errors.New("git exited with code 128")
+}
+
+func Test_source_open(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/labels.csv" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(csvData)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		arg    string
+		stdout string
+		want   string
+		wantE  bool
+	}{
+		{
+			name: "file",
+			arg:  "labels.csv",
+			want: "file",
+		},
+		{
+			name: "relative file",
+			arg:  "./labels.csv",
+			want: "file",
+		},
+		{
+			name:  "missing file",
+			arg:   "missing.csv",
+			wantE: true,
+		},
+		{
+			name: "stdin",
+			arg:  "-",
+			want: "stdin",
+		},
+		{
+			name:   "repo",
+			arg:    "heaths/.github:labels.csv@main",
+			stdout: `{"type": "file", "encoding": "base64", "content": "cmVwbw=="}`,
+			want:   "repo",
+		},
+		{
+			name:  "repo error",
+			arg:   "heaths/.github:labels.csv@main",
+			wantE: true,
+		},
+		{
+			name: "git",
+			arg:  "git:labels.csv@main",
+			want: "git",
+		},
+		{
+			name:  "git error",
+			arg:   "git:labels.csv@missing",
+			wantE: true,
+		},
+		{
+			name: "url",
+			arg:  server.URL + "/labels.csv",
+			want: string(csvData),
+		},
+		{
+			name:  "url not found",
+			arg:   server.URL + "/missing.csv",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, _, _ := iostreams.Test()
+			stdin.WriteString("stdin")
+
+			mock := &github.Mock{
+				Stdout: *bytes.NewBufferString(tt.stdout),
+			}
+
+			opts := &importOptions{
+				client: github.New(mock),
+				fs: fstest.MapFS{
+					"labels.csv": &fstest.MapFile{Data: []byte("file")},
+				},
+				git: &mockGit{
+					files: map[string]string{
+						"main:labels.csv": "git",
+					},
+				},
+				http: server.Client(),
+				io:   io,
+			}
+
+			src, err := parseSource(tt.arg)
+			if err != nil {
+				t.Fatalf("parseSource() error = %v", err)
+			}
+
+			r, err := src.open(opts)
+			if (err != nil) != tt.wantE {
+				t.Errorf("source.open() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+			if tt.wantE {
+				return
+			}
+			defer r.Close()
+
+			got := &bytes.Buffer{}
+			if _, err := got.ReadFrom(r); err != nil || got.String() != tt.want {
+				t.Errorf("source.open() read %q, error = %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_source_fs(t *testing.T) {
+	base := "labels:\n  - name: bug\n    color: d73a4a\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/labels/base.yml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(base))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		arg      string
+		wantName string
+	}{
+		{
+			name:     "file",
+			arg:      "./labels/service.yml",
+			wantName: "labels/service.yml",
+		},
+		{
+			name:     "git",
+			arg:      "git:labels/service.yml@main",
+			wantName: "labels/service.yml",
+		},
+		{
+			name:     "url",
+			arg:      server.URL + "/labels/service.yml?token=abc",
+			wantName: "service.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &importOptions{
+				fs: fstest.MapFS{
+					"labels/base.yml": &fstest.MapFile{Data: []byte(base)},
+				},
+				git: &mockGit{
+					files: map[string]string{
+						"main:labels/base.yml": base,
+					},
+				},
+				http: server.Client(),
+			}
+
+			src, err := parseSource(tt.arg)
+			if err != nil {
+				t.Fatalf("parseSource() error = %v", err)
+			}
+
+			fsys, name := src.fs(opts)
+			if name != tt.wantName {
+				t.Errorf("source.fs() name = %q, want %q", name, tt.wantName)
+			}
+
+			data, err := fs.ReadFile(fsys, path.Join(path.Dir(name), "base.yml"))
+			if err != nil {
+				t.Errorf("ReadFile() error = %v", err)
+			} else if string(data) != base {
+				t.Errorf("ReadFile() = %q, want %q", data, base)
+			}
+
+			if _, err := fs.ReadFile(fsys, "../base.yml"); err == nil {
+				t.Errorf("ReadFile() expected error")
+			}
+		})
+	}
+}
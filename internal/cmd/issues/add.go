@@ -0,0 +1,152 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type addOptions struct {
+	names     []string
+	palette   string
+	selection selection
+
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+func AddCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &addOptions{}
+	cmd := &cobra.Command{
+		Use:   "add <name>...",
+		Short: "Add labels to issues or pull requests, creating labels that do not exist",
+		Long: heredoc.Doc(`
+			Add labels to issues or pull requests, creating labels that do not exist.
+
+			Labels that do not exist are created with a random color, or a random color from --palette.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label add bug --issue 12
+			$ gh label add bug "area: cli" --issue 12,13 --pr 14
+			$ gh label add needs-triage --search "is:open no:label" --palette okabe-ito
+		`),
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.palette != "" {
+				if err := utils.ValidatePalette(opts.palette); err != nil {
+					return fmt.Errorf(`invalid flag "palette": %s`, err)
+				}
+			}
+
+			return opts.selection.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.names = args
+
+			return add(globalOpts, opts)
+		},
+	}
+
+	opts.selection.addFlags(cmd)
+	cmd.Flags().StringVarP(&opts.palette, "palette", "p", "", fmt.Sprintf("Choose colors for labels that do not exist from the palette. One of %v.", utils.PaletteNames()))
+
+	return cmd
+}
+
+func add(globalOpts *options.GlobalOptions, opts *addOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	owner, repo := globalOpts.Repo()
+	numbers, err := opts.selection.numbers(opts.client, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	names, err := ensureLabels(opts.client, opts.io, opts.palette, opts.names)
+	if err != nil {
+		return err
+	}
+
+	failures := 0
+	for _, number := range numbers {
+		if _, err := opts.client.AddIssueLabels(number, names); err != nil {
+			failures++
+			fmt.Fprintf(opts.io.ErrOut, "Failed to add labels to #%d; error: %s\n", number, err)
+			continue
+		}
+
+		if opts.io.IsStdoutTTY() {
+			fmt.Fprintf(opts.io.Out, "Added %s to #%d\n", quote(names), number)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("failed to add labels to %d of %d issue(s)", failures, len(numbers))
+	}
+
+	return nil
+}
+
+// ensureLabels creates labels that do not exist and returns the names of existing labels as they are cased in the repository.
+func ensureLabels(client *github.Client, io *iostreams.IOStreams, palette string, names []string) ([]string, error) {
+	labels, err := client.ListLabels("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels; error: %w", err)
+	}
+
+	existing := make(map[string]string)
+	for _, label := range labels {
+		existing[strings.ToLower(label.Name)] = label.Name
+	}
+
+	resolved := make([]string, 0, len(names))
+	for _, name := range names {
+		if n, ok := existing[strings.ToLower(name)]; ok {
+			resolved = append(resolved, n)
+			continue
+		}
+
+		color := utils.RandomColor()
+		if palette != "" {
+			if color, err = utils.RandomPaletteColor(palette); err != nil {
+				return nil, err
+			}
+		}
+
+		label, err := client.CreateLabel(github.Label{
+			Name:  name,
+			Color: color,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create label %q; error: %w", name, err)
+		}
+
+		if io.IsStdoutTTY() {
+			fmt.Fprintf(io.Out, "Created label '%s'\n", label.Name)
+		}
+
+		existing[strings.ToLower(name)] = name
+		resolved = append(resolved, name)
+	}
+
+	return resolved, nil
+}
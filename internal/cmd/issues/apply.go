@@ -0,0 +1,271 @@
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	cliutils "github.com/cli/cli/utils"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type applyOptions struct {
+	search      string
+	add         []string
+	remove      []string
+	palette     string
+	concurrency int
+	dryRun      bool
+
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+// change describes labels to add to or remove from an issue.
+type change struct {
+	issue  github.Issue
+	add    []string
+	remove []string
+}
+
+func ApplyCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &applyOptions{}
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Add and remove labels on all issues and pull requests matching a search query",
+		Long: heredoc.Doc(`
+			Add and remove labels on all issues and pull requests matching a search query.
+
+			Only issues and pull requests that would change are updated.
+			Labels to add that do not exist are created like with "add".
+		`),
+		Example: heredoc.Doc(`
+			$ gh label apply --search "is:open label:needs-triage created:<2026-01-01" --add stale --remove needs-triage
+			$ gh label apply --search "is:pr is:open" --add "needs review" --dry-run
+			$ gh label apply --search "is:closed label:stale" --remove stale --concurrency 4
+		`),
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.search == "" {
+				return errors.New(`flag "search" is required`)
+			}
+
+			if len(opts.add) == 0 && len(opts.remove) == 0 {
+				return errors.New(`at least one of flags "add" or "remove" is required`)
+			}
+
+			for _, name := range opts.add {
+				for _, other := range opts.remove {
+					if strings.EqualFold(name, other) {
+						return fmt.Errorf("cannot both add and remove label %q", name)
+					}
+				}
+			}
+
+			if opts.concurrency < 1 {
+				return fmt.Errorf(`invalid flag "concurrency": must be at least 1, got %d`, opts.concurrency)
+			}
+
+			if opts.palette != "" {
+				if err := utils.ValidatePalette(opts.palette); err != nil {
+					return fmt.Errorf(`invalid flag "palette": %s`, err)
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return apply(globalOpts, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.search, "search", "", "", `Issues and pull requests matching a search `+"`query`"+` in the repository like "is:open label:bug".`)
+	cmd.Flags().StringSliceVarP(&opts.add, "add", "", nil, "Labels to add. Repeat or separate with commas for more labels.")
+	cmd.Flags().StringSliceVarP(&opts.remove, "remove", "", nil, "Labels to remove. Repeat or separate with commas for more labels.")
+	cmd.Flags().StringVarP(&opts.palette, "palette", "p", "", fmt.Sprintf("Choose colors for labels to add that do not exist from the palette. One of %v.", utils.PaletteNames()))
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "", 4, "Number of issues and pull requests to update at the same time.")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "List issues and pull requests that would change without changing them.")
+
+	return cmd
+}
+
+func apply(globalOpts *options.GlobalOptions, opts *applyOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	owner, repo := globalOpts.Repo()
+	issues, err := searchIssues(opts.client, owner, repo, opts.search)
+	if err != nil {
+		return err
+	}
+
+	changes := planChanges(issues, opts.add, opts.remove)
+	if opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Found %d matching, %d to change\n", len(issues), len(changes))
+	}
+
+	if opts.dryRun {
+		return printChanges(opts.io, changes)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	add := opts.add
+	if len(add) > 0 {
+		if add, err = ensureLabels(opts.client, opts.io, opts.palette, add); err != nil {
+			return err
+		}
+	}
+
+	concurrency := opts.concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	completed := 0
+	failures := 0
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, c := range changes {
+		c := c
+		c.add = resolveNames(c.add, add)
+
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := applyChange(opts.client, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			completed++
+			if err != nil {
+				failures++
+				fmt.Fprintf(opts.io.ErrOut, "Failed to update #%d; error: %s\n", c.issue.Number, err)
+			} else if opts.io.IsStdoutTTY() {
+				fmt.Fprintf(opts.io.Out, "[%d/%d] Updated #%d %s\n", completed, len(changes), c.issue.Number, c.summary())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Updated %d, failed to update %d issue(s)\n", len(changes)-failures, failures)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("failed to update %d of %d issue(s)", failures, len(changes))
+	}
+
+	return nil
+}
+
+// planChanges returns the labels to add to or remove from each issue, omitting issues that would not change.
+func planChanges(issues []github.Issue, add, remove []string) []change {
+	var changes []change
+	for _, issue := range issues {
+		applied := make(map[string]bool)
+		for _, label := range issue.Labels {
+			applied[strings.ToLower(label.Name)] = true
+		}
+
+		c := change{issue: issue}
+		for _, name := range add {
+			if !applied[strings.ToLower(name)] {
+				c.add = append(c.add, name)
+			}
+		}
+		for _, name := range remove {
+			if applied[strings.ToLower(name)] {
+				c.remove = append(c.remove, name)
+			}
+		}
+
+		if len(c.add) > 0 || len(c.remove) > 0 {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// resolveNames returns names as they are cased in resolved.
+func resolveNames(names, resolved []string) []string {
+	result := make([]string, len(names))
+	for i, name := range names {
+		result[i] = name
+		for _, r := range resolved {
+			if strings.EqualFold(name, r) {
+				result[i] = r
+				break
+			}
+		}
+	}
+	return result
+}
+
+func applyChange(client *github.Client, c change) error {
+	if len(c.add) > 0 {
+		if _, err := client.AddIssueLabels(c.issue.Number, c.add); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range c.remove {
+		if _, err := client.RemoveIssueLabel(c.issue.Number, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// summary returns the change like "+stale -needs-triage".
+func (c change) summary() string {
+	var parts []string
+	for _, name := range c.add {
+		parts = append(parts, "+"+name)
+	}
+	for _, name := range c.remove {
+		parts = append(parts, "-"+name)
+	}
+	return strings.Join(parts, " ")
+}
+
+func printChanges(io *iostreams.IOStreams, changes []change) error {
+	printer := cliutils.NewTablePrinter(io)
+	for _, c := range changes {
+		printer.AddField(fmt.Sprintf("#%d", c.issue.Number), nil, nil)
+		printer.AddField(c.issue.Title, nil, nil)
+		printer.AddField(c.summary(), nil, nil)
+		printer.EndRow()
+	}
+	return printer.Render()
+}
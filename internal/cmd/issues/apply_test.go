@@ -0,0 +1,124 @@
+package issues
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func Test_apply(t *testing.T) {
+	search := heredoc.Doc(`{
+		"items": [
+			{"number": 1, "title": "Crash", "html_url": "https://github.com/heaths/gh-label/issues/1", "labels": [{"name": "needs-triage"}]},
+			{"number": 2, "title": "Hang", "html_url": "https://github.com/heaths/gh-label/issues/2", "labels": [{"name": "Stale"}]},
+			{"number": 3, "title": "Typo", "html_url": "https://github.com/heaths/gh-label/pull/3", "labels": [{"name": "needs-triage"}, {"name": "stale"}]}
+		]
+	}`)
+
+	tests := []struct {
+		name        string
+		search      string
+		dryRun      bool
+		tty         bool
+		failing     int
+		wantAdded   []string
+		wantRemoved []string
+		wantW       string
+		wantE       bool
+	}{
+		{
+			name:        "apply",
+			wantAdded:   []string{"#1: stale"},
+			wantRemoved: []string{"#1: needs-triage", "#3: needs-triage"},
+		},
+		{
+			name:        "apply (TTY)",
+			tty:         true,
+			wantAdded:   []string{"#1: stale"},
+			wantRemoved: []string{"#1: needs-triage", "#3: needs-triage"},
+			wantW: heredoc.Doc(`
+				Found 3 matching, 2 to change
+				Created label 'stale'
+				[1/2] Updated #1 +stale -needs-triage
+				[2/2] Updated #3 -needs-triage
+				Updated 2, failed to update 0 issue(s)
+			`),
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			wantW: heredoc.Docf(`
+				#1%[1]sCrash%[1]s+stale -needs-triage
+				#3%[1]sTypo%[1]s-needs-triage
+			`, "\t"),
+		},
+		{
+			name:        "failure",
+			failing:     3,
+			wantAdded:   []string{"#1: stale"},
+			wantRemoved: []string{"#1: needs-triage"},
+			wantE:       true,
+		},
+		{
+			name: "other repository",
+			search: heredoc.Doc(`{
+				"items": [
+					{"number": 1, "title": "Crash", "html_url": "https://github.com/heaths/gh-label/issues/1", "labels": [{"name": "needs-triage"}]},
+					{"number": 2, "title": "Hang", "html_url": "https://github.com/cli/cli/issues/2", "labels": [{"name": "needs-triage"}]}
+				]
+			}`),
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(tt.tty)
+
+			mock := &issuesMock{
+				labels:  `[{"name": "needs-triage", "color": "fbca04"}]`,
+				search:  search,
+				failing: tt.failing,
+			}
+			if tt.search != "" {
+				mock.search = tt.search
+			}
+
+			opts := &applyOptions{
+				search:      "is:open",
+				add:         []string{"stale"},
+				remove:      []string{"needs-triage"},
+				concurrency: 1,
+				dryRun:      tt.dryRun,
+
+				client: github.New(mock),
+				io:     io,
+			}
+
+			if err := apply(repoOptions(t), opts); (err != nil) != tt.wantE {
+				t.Errorf("apply() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			sort.Strings(mock.added)
+			sort.Strings(mock.removed)
+
+			if !reflect.DeepEqual(mock.added, tt.wantAdded) {
+				t.Errorf("apply() added %q, want %q", mock.added, tt.wantAdded)
+			}
+
+			if !reflect.DeepEqual(mock.removed, tt.wantRemoved) {
+				t.Errorf("apply() removed %q, want %q", mock.removed, tt.wantRemoved)
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("apply() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
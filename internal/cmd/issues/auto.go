@@ -0,0 +1,220 @@
+package issues
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/rules"
+	"github.com/heaths/gh-label/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type autoOptions struct {
+	numbers []int
+	rules   string
+	palette string
+	dryRun  bool
+
+	// test
+	client *github.Client
+	fs     fs.FS
+	io     *iostreams.IOStreams
+}
+
+func AutoCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &autoOptions{}
+	cmd := &cobra.Command{
+		Use:   "auto [number...]",
+		Short: "Add labels to issues and pull requests matching rules",
+		Long: heredoc.Docf(`
+			Add labels to issues and pull requests matching rules, or all open issues and pull requests if no numbers are passed.
+
+			Rules are read from %[1]s%[2]s%[1]s by default. Each rule adds its labels if all its conditions match:
+
+			  rules:
+			    - labels: [bug]
+			      title: "(?i)crash|panic"         # regular expression
+			    - labels: [needs-triage]
+			      type: issue                      # issue or pull-request
+			      author-association: [NONE, FIRST_TIME_CONTRIBUTOR]
+			    - labels: [documentation]
+			      files: ["docs/**", "*.md"]       # changed files in pull requests
+			    - labels: [dependencies]
+			      branch: "^dependabot/"           # regular expression for pull request branches
+
+			A "body" condition matches the body of issues and pull requests like "title".
+			Labels that do not exist are created like with "add".
+		`, "`", rules.DefaultPath),
+		Example: heredoc.Doc(`
+			$ gh label auto 12 13
+			$ gh label auto --dry-run
+			$ gh label auto --rules ./triage.yml
+		`),
+		Args: cobra.ArbitraryArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			for _, arg := range args {
+				number, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+				if err != nil || number < 1 {
+					return fmt.Errorf("expected an issue or pull request number, got %q", arg)
+				}
+				opts.numbers = append(opts.numbers, number)
+			}
+
+			if opts.palette != "" {
+				if err := utils.ValidatePalette(opts.palette); err != nil {
+					return fmt.Errorf(`invalid flag "palette": %s`, err)
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return auto(globalOpts, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.rules, "rules", "", rules.DefaultPath, "Path to the rules `file`.")
+	cmd.Flags().StringVarP(&opts.palette, "palette", "p", "", fmt.Sprintf("Choose colors for labels that do not exist from the palette. One of %v.", utils.PaletteNames()))
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "List issues and pull requests that would change without changing them.")
+
+	return cmd
+}
+
+func auto(globalOpts *options.GlobalOptions, opts *autoOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.fs == nil {
+		pwd, err := os.Getwd()
+		if err != nil {
+			pwd = "/"
+		}
+		opts.fs = os.DirFS(pwd)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	if opts.rules == "" {
+		opts.rules = rules.DefaultPath
+	}
+
+	file, err := utils.OpenFile(opts.fs, opts.rules)
+	if err != nil {
+		return fmt.Errorf("failed to open rules %q; error: %w", opts.rules, err)
+	}
+	defer file.Close()
+
+	rs, err := rules.Read(file)
+	if err != nil {
+		return fmt.Errorf("failed to read rules %q; error: %w", opts.rules, err)
+	}
+
+	var issues []github.Issue
+	if len(opts.numbers) == 0 {
+		if issues, err = opts.client.ListIssues("open"); err != nil {
+			return fmt.Errorf("failed to list issues; error: %w", err)
+		}
+	} else {
+		for _, number := range opts.numbers {
+			issue, err := opts.client.GetIssue(number)
+			if err != nil {
+				return fmt.Errorf("failed to get #%d; error: %w", number, err)
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	var changes []change
+	var names []string
+	for _, issue := range issues {
+		subject := rules.Subject{
+			Title:             issue.Title,
+			Body:              issue.Body,
+			AuthorAssociation: issue.AuthorAssociation,
+			PullRequest:       issue.PullRequest,
+		}
+
+		for _, label := range issue.Labels {
+			subject.Labels = append(subject.Labels, label.Name)
+		}
+
+		if issue.PullRequest && rs.NeedsPullRequest() {
+			pr, err := opts.client.GetPullRequest(issue.Number)
+			if err != nil {
+				return fmt.Errorf("failed to get pull request #%d; error: %w", issue.Number, err)
+			}
+			subject.Branch = pr.Branch
+			subject.Files = pr.Files
+		}
+
+		if add := rs.Evaluate(subject); len(add) > 0 {
+			changes = append(changes, change{issue: issue, add: add})
+			names = append(names, add...)
+		}
+	}
+
+	if opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Evaluated %d, %d to change\n", len(issues), len(changes))
+	}
+
+	if opts.dryRun {
+		return printChanges(opts.io, changes)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if names, err = ensureLabels(opts.client, opts.io, opts.palette, distinct(names)); err != nil {
+		return err
+	}
+
+	failures := 0
+	for _, c := range changes {
+		c.add = resolveNames(c.add, names)
+		if err := applyChange(opts.client, c); err != nil {
+			failures++
+			fmt.Fprintf(opts.io.ErrOut, "Failed to add labels to #%d; error: %s\n", c.issue.Number, err)
+			continue
+		}
+
+		if opts.io.IsStdoutTTY() {
+			fmt.Fprintf(opts.io.Out, "Added %s to #%d\n", quote(c.add), c.issue.Number)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("failed to add labels to %d of %d issue(s)", failures, len(changes))
+	}
+
+	return nil
+}
+
+// distinct returns names without case-insensitive duplicates in order.
+func distinct(names []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, name := range names {
+		if key := strings.ToLower(name); !seen[key] {
+			seen[key] = true
+			result = append(result, name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package issues
+
+// cSpell:ignore fstest
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/rules"
+)
+
+func Test_auto(t *testing.T) {
+	fs := fstest.MapFS{
+		rules.DefaultPath: &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				rules:
+				  - labels: [bug]
+				    title: "(?i)crash"
+				  - labels: [documentation]
+				    files: ["*.md"]
+			`)),
+		},
+		"invalid.yml": &fstest.MapFile{
+			Data: []byte("rules:\n  - labels: [bug]\n"),
+		},
+	}
+
+	open := heredoc.Doc(`[
+		{"number": 1, "title": "Crash on export", "labels": [{"name": "bug"}]},
+		{"number": 2, "title": "Update README", "pull_request": {}},
+		{"number": 3, "title": "Hang"}
+	]`)
+
+	tests := []struct {
+		name      string
+		numbers   []int
+		rules     string
+		dryRun    bool
+		wantAdded []string
+		wantW     string
+		wantE     bool
+	}{
+		{
+			name:      "open",
+			wantAdded: []string{"#2: documentation"},
+			wantW: heredoc.Doc(`
+				Evaluated 3, 1 to change
+				Created label 'documentation'
+				Added 'documentation' to #2
+			`),
+		},
+		{
+			name:      "numbers",
+			numbers:   []int{4},
+			wantAdded: []string{"#4: bug"},
+			wantW: heredoc.Doc(`
+				Evaluated 1, 1 to change
+				Added 'bug' to #4
+			`),
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			wantW: heredoc.Doc(`
+				Evaluated 3, 1 to change
+				#2  Update README  +documentation
+			`),
+		},
+		{
+			name:   "relative rules",
+			rules:  "./" + rules.DefaultPath,
+			dryRun: true,
+			wantW: heredoc.Doc(`
+				Evaluated 3, 1 to change
+				#2  Update README  +documentation
+			`),
+		},
+		{
+			name:  "invalid rules",
+			rules: "invalid.yml",
+			wantE: true,
+		},
+		{
+			name:  "missing rules",
+			rules: "missing.yml",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(true)
+
+			mock := &issuesMock{
+				labels: `[{"name": "bug", "color": "d73a4a"}]`,
+				open:   open,
+				files:  `[{"filename": "README.md"}]`,
+			}
+
+			opts := &autoOptions{
+				numbers: tt.numbers,
+				rules:   tt.rules,
+				dryRun:  tt.dryRun,
+
+				client: github.New(mock),
+				fs:     fs,
+				io:     io,
+			}
+
+			if err := auto(&options.GlobalOptions{}, opts); (err != nil) != tt.wantE {
+				t.Errorf("auto() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(mock.added, tt.wantAdded) {
+				t.Errorf("auto() added %q, want %q", mock.added, tt.wantAdded)
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("auto() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
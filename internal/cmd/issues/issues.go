@@ -0,0 +1,101 @@
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// selection selects issues and pull requests by number or search query.
+type selection struct {
+	issues []int
+	prs    []int
+	search string
+}
+
+func (s *selection) addFlags(cmd *cobra.Command) {
+	cmd.Flags().IntSliceVarP(&s.issues, "issue", "", nil, "Issue `number`. Repeat or separate with commas for more issues.")
+	cmd.Flags().IntSliceVarP(&s.prs, "pr", "", nil, "Pull request `number`. Repeat or separate with commas for more pull requests.")
+	cmd.Flags().StringVarP(&s.search, "search", "", "", `Issues and pull requests matching a search `+"`query`"+` in the repository like "is:open label:bug".`)
+}
+
+func (s *selection) validate() error {
+	if len(s.issues) == 0 && len(s.prs) == 0 && s.search == "" {
+		return errors.New(`at least one of flags "issue", "pr", or "search" is required`)
+	}
+
+	for _, number := range append(s.issues, s.prs...) {
+		if number < 1 {
+			return fmt.Errorf("issue and pull request numbers must be positive, got %d", number)
+		}
+	}
+
+	return nil
+}
+
+// numbers returns the sorted, distinct numbers of selected issues and pull requests in the repository owner/repo.
+func (s *selection) numbers(client *github.Client, owner, repo string) ([]int, error) {
+	seen := make(map[int]bool)
+	var numbers []int
+	add := func(number int) {
+		if !seen[number] {
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+
+	for _, number := range s.issues {
+		add(number)
+	}
+
+	for _, number := range s.prs {
+		add(number)
+	}
+
+	if s.search != "" {
+		issues, err := searchIssues(client, owner, repo, s.search)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(issues) == 0 && len(numbers) == 0 {
+			return nil, fmt.Errorf("no issues or pull requests match %q", s.search)
+		}
+
+		for _, issue := range issues {
+			add(issue.Number)
+		}
+	}
+
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// searchIssues returns issues and pull requests matching query, or an error if any are not in the repository owner/repo.
+func searchIssues(client *github.Client, owner, repo, query string) ([]github.Issue, error) {
+	issues, err := client.SearchIssues(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues; error: %w", err)
+	}
+
+	for _, issue := range issues {
+		if !issue.InRepo(owner, repo) {
+			return nil, fmt.Errorf("search returned #%d from another repository: %s", issue.Number, issue.URL)
+		}
+	}
+
+	return issues, nil
+}
+
+// quote returns names quoted like "'bug', 'triage'".
+func quote(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,295 @@
+package issues
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+)
+
+// issuesMock records labels added to or removed from issues.
+type issuesMock struct {
+	github.Mock
+
+	labels  string
+	search  string
+	open    string
+	files   string
+	failing int
+
+	created []string
+	added   []string
+	removed []string
+}
+
+func (m *issuesMock) ListLabels(substr string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`{"data":{"repository":{"labels":{"nodes":` + m.labels + `}}}}`), nil
+}
+
+func (m *issuesMock) CreateLabel(label github.Label) (bytes.Buffer, error) {
+	m.created = append(m.created, label.Name)
+	return *bytes.NewBufferString(fmt.Sprintf(`{"name": %q, "color": %q}`, label.Name, label.Color)), nil
+}
+
+func (m *issuesMock) SearchIssues(query string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(m.search), nil
+}
+
+func (m *issuesMock) ListIssues(state string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(m.open), nil
+}
+
+func (m *issuesMock) GetIssue(number int) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(fmt.Sprintf(`{"number": %d, "title": "Crash on import"}`, number)), nil
+}
+
+func (m *issuesMock) GetPullRequest(number int) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`{"head": {"ref": "docs/readme"}}`), nil
+}
+
+func (m *issuesMock) ListPullRequestFiles(number int) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(m.files), nil
+}
+
+func (m *issuesMock) AddIssueLabels(number int, names []string) (bytes.Buffer, error) {
+	if number == m.failing {
+		return bytes.Buffer{}, errors.New("HTTP 403")
+	}
+	m.added = append(m.added, fmt.Sprintf("#%d: %s", number, strings.Join(names, ", ")))
+	return *bytes.NewBufferString("[]"), nil
+}
+
+func (m *issuesMock) RemoveIssueLabel(number int, name string) error {
+	if number == m.failing {
+		return errors.New("HTTP 403")
+	}
+	if name == "missing" {
+		return errors.New("HTTP 404")
+	}
+	m.removed = append(m.removed, fmt.Sprintf("#%d: %s", number, name))
+	return nil
+}
+
+const searchData = `{"items": [
+	{"number": 3, "title": "Crash", "html_url": "https://github.com/heaths/gh-label/issues/3"},
+	{"number": 1, "title": "Hang", "html_url": "https://github.com/heaths/gh-label/pull/1"}
+]}`
+
+// repoOptions returns global options for the repository heaths/gh-label.
+func repoOptions(t *testing.T) *options.GlobalOptions {
+	t.Helper()
+
+	cmd := &cobra.Command{
+		Use:  "label",
+		RunE: func(cmd *cobra.Command, args []string) error { return nil },
+	}
+	opts := options.New(cmd)
+	cmd.SetArgs([]string{"--repo", "heaths/gh-label"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	return opts
+}
+
+func Test_add(t *testing.T) {
+	tests := []struct {
+		name        string
+		names       []string
+		selection   selection
+		search      string
+		failing     int
+		wantCreated []string
+		wantAdded   []string
+		wantW       string
+		wantE       bool
+	}{
+		{
+			name:      "existing",
+			names:     []string{"BUG"},
+			selection: selection{issues: []int{2}, prs: []int{2, 4}},
+			wantAdded: []string{"#2: bug", "#4: bug"},
+			wantW: heredoc.Doc(`
+				Added 'bug' to #2
+				Added 'bug' to #4
+			`),
+		},
+		{
+			name:        "create missing",
+			names:       []string{"bug", "needs-triage"},
+			selection:   selection{search: "is:open"},
+			wantCreated: []string{"needs-triage"},
+			wantAdded:   []string{"#1: bug, needs-triage", "#3: bug, needs-triage"},
+			wantW: heredoc.Doc(`
+				Created label 'needs-triage'
+				Added 'bug', 'needs-triage' to #1
+				Added 'bug', 'needs-triage' to #3
+			`),
+		},
+		{
+			name:      "failure",
+			names:     []string{"bug"},
+			selection: selection{issues: []int{1, 2}},
+			failing:   1,
+			wantAdded: []string{"#2: bug"},
+			wantW:     "Added 'bug' to #2\n",
+			wantE:     true,
+		},
+		{
+			name:      "other repository",
+			names:     []string{"bug"},
+			selection: selection{issues: []int{2}, search: "is:open"},
+			search:    `{"items": [{"number": 1, "title": "Hang", "html_url": "https://github.com/cli/cli/issues/1"}]}`,
+			wantE:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(true)
+
+			mock := &issuesMock{
+				labels:  `[{"name": "bug", "color": "d73a4a"}]`,
+				search:  searchData,
+				failing: tt.failing,
+			}
+			if tt.search != "" {
+				mock.search = tt.search
+			}
+
+			opts := &addOptions{
+				names:     tt.names,
+				palette:   "github",
+				selection: tt.selection,
+
+				client: github.New(mock),
+				io:     io,
+			}
+
+			if err := add(repoOptions(t), opts); (err != nil) != tt.wantE {
+				t.Errorf("add() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(mock.created, tt.wantCreated) {
+				t.Errorf("add() created %q, want %q", mock.created, tt.wantCreated)
+			}
+
+			if !reflect.DeepEqual(mock.added, tt.wantAdded) {
+				t.Errorf("add() added %q, want %q", mock.added, tt.wantAdded)
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("add() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func Test_remove(t *testing.T) {
+	tests := []struct {
+		name        string
+		names       []string
+		selection   selection
+		failing     int
+		wantRemoved []string
+		wantW       string
+		wantE       bool
+	}{
+		{
+			name:        "search",
+			names:       []string{"needs-triage", "missing"},
+			selection:   selection{search: "label:needs-triage"},
+			wantRemoved: []string{"#1: needs-triage", "#3: needs-triage"},
+			wantW: heredoc.Doc(`
+				Removed 'needs-triage' from #1
+				Removed 'needs-triage' from #3
+			`),
+		},
+		{
+			name:        "failure",
+			names:       []string{"needs-triage"},
+			selection:   selection{issues: []int{1, 2}},
+			failing:     2,
+			wantRemoved: []string{"#1: needs-triage"},
+			wantW:       "Removed 'needs-triage' from #1\n",
+			wantE:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(true)
+
+			mock := &issuesMock{
+				search:  searchData,
+				failing: tt.failing,
+			}
+
+			opts := &removeOptions{
+				names:     tt.names,
+				selection: tt.selection,
+
+				client: github.New(mock),
+				io:     io,
+			}
+
+			if err := remove(repoOptions(t), opts); (err != nil) != tt.wantE {
+				t.Errorf("remove() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(mock.removed, tt.wantRemoved) {
+				t.Errorf("remove() removed %q, want %q", mock.removed, tt.wantRemoved)
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("remove() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func Test_selection_validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		selection selection
+		wantE     bool
+	}{
+		{
+			name:  "empty",
+			wantE: true,
+		},
+		{
+			name:      "issue",
+			selection: selection{issues: []int{1}},
+		},
+		{
+			name:      "search",
+			selection: selection{search: "is:open"},
+		},
+		{
+			name:      "invalid number",
+			selection: selection{prs: []int{0}},
+			wantE:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.selection.validate(); (err != nil) != tt.wantE {
+				t.Errorf("validate() error = %v, wantE %v", err, tt.wantE)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package issues
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+)
+
+type removeOptions struct {
+	names     []string
+	selection selection
+
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+func RemoveCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &removeOptions{}
+	cmd := &cobra.Command{
+		Use:   "remove <name>...",
+		Short: "Remove labels from issues or pull requests",
+		Example: heredoc.Doc(`
+			$ gh label remove needs-triage --issue 12
+			$ gh label remove needs-triage --search "is:closed label:needs-triage"
+		`),
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.selection.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.names = args
+
+			return remove(globalOpts, opts)
+		},
+	}
+
+	opts.selection.addFlags(cmd)
+
+	return cmd
+}
+
+func remove(globalOpts *options.GlobalOptions, opts *removeOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	owner, repo := globalOpts.Repo()
+	numbers, err := opts.selection.numbers(opts.client, owner, repo)
+	if err != nil {
+		return err
+	}
+
+	failures := 0
+	for _, number := range numbers {
+		var removed []string
+		failed := false
+		for _, name := range opts.names {
+			ok, err := opts.client.RemoveIssueLabel(number, name)
+			if err != nil {
+				failed = true
+				fmt.Fprintf(opts.io.ErrOut, "Failed to remove label %q from #%d; error: %s\n", name, number, err)
+				continue
+			}
+
+			if ok {
+				removed = append(removed, name)
+			}
+		}
+
+		if failed {
+			failures++
+		}
+
+		if len(removed) > 0 && opts.io.IsStdoutTTY() {
+			fmt.Fprintf(opts.io.Out, "Removed %s from #%d\n", quote(removed), number)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("failed to remove labels from %d of %d issue(s)", failures, len(numbers))
+	}
+
+	return nil
+}
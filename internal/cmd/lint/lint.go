@@ -0,0 +1,274 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	cliutils "github.com/cli/cli/utils"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/lint"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type lintOptions struct {
+	path        string
+	rulesPath   string
+	minContrast float64
+	minDistance float64
+	format      string
+	fix         bool
+
+	// Set when flags override rules.
+	minContrastSet bool
+	minDistanceSet bool
+
+	// test
+	client *github.Client
+	fs     fs.FS
+	io     *iostreams.IOStreams
+}
+
+func LintCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &lintOptions{}
+	cmd := &cobra.Command{
+		Use:   "lint [path]",
+		Short: "Check labels in the repository, or in the file [path], for readability, distinctness, and naming conventions",
+		Long: heredoc.Doc(`
+			Check labels in the repository, or in the file [path], for readability, distinctness, and naming conventions.
+
+			Labels are reported when the WCAG contrast ratio between the label color and the
+			black or white text GitHub renders on it is too low, or when two label colors are
+			too similar with normal vision or simulated protanopia, deuteranopia, or tritanopia.
+
+			Naming conventions are read from a YAML or JSON rules file passed to --rules:
+
+			  scopes: [area, priority, type]   # names must start with a scope
+			  scope-separator: ":"             # follows the scope; default is ":"
+			  allowed-characters: "a-z0-9 :-"  # regular expression character class
+			  lowercase: true
+			  max-name-length: 50
+			  require-description: true
+			  max-description-length: 100      # default is GitHub's limit of 100
+			  min-contrast: 4.5
+			  min-distance: 10
+			  ignore: [good first issue]       # names exempt from naming rules
+
+			Pass --fix to apply safe fixes like trimming whitespace or changing case to labels in the repository.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label lint
+			$ gh label lint --min-contrast 3 --min-distance 15
+			$ gh label lint --format json
+			$ gh label lint --rules .github/label-rules.yml --fix
+			$ gh label lint ./labels.csv --rules .github/label-rules.yml
+		`),
+		Annotations: map[string]string{
+			options.OfflineAnnotation: "true",
+		},
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.format != "table" && opts.format != "json" {
+				return fmt.Errorf(`invalid flag "format": expected "table" or "json", got %q`, opts.format)
+			}
+
+			if len(args) > 0 {
+				opts.path = args[0]
+				if opts.fix {
+					return fmt.Errorf(`flag "fix" is not supported when linting a file`)
+				}
+			}
+
+			opts.minContrastSet = cmd.Flags().Changed("min-contrast")
+			opts.minDistanceSet = cmd.Flags().Changed("min-distance")
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Labels are read from the repository unless a file is passed.
+			if opts.path == "" {
+				if err := globalOpts.ResolveRepo(); err != nil {
+					return err
+				}
+			}
+			return _lint(globalOpts, opts)
+		},
+	}
+
+	defaults := lint.DefaultRules()
+	cmd.Flags().StringVarP(&opts.rulesPath, "rules", "", "", "Path to a YAML or JSON file of naming conventions.")
+	cmd.Flags().Float64VarP(&opts.minContrast, "min-contrast", "", defaults.MinContrast, "Minimum WCAG contrast ratio between the label and text colors.")
+	cmd.Flags().Float64VarP(&opts.minDistance, "min-distance", "", defaults.MinDistance, "Minimum CIE76 color difference (delta E) between any two labels.")
+	cmd.Flags().StringVarP(&opts.format, "format", "", "table", `Format of the findings: "table" or "json".`)
+	cmd.Flags().BoolVarP(&opts.fix, "fix", "", false, "Apply safe fixes to labels in the repository.")
+
+	return cmd
+}
+
+func _lint(globalOpts *options.GlobalOptions, opts *lintOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.fs == nil {
+		pwd, err := os.Getwd()
+		if err != nil {
+			pwd = "/"
+		}
+		opts.fs = os.DirFS(pwd)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	rules := lint.DefaultRules()
+	if opts.rulesPath != "" {
+		file, err := utils.OpenFile(opts.fs, opts.rulesPath)
+		if err != nil {
+			return fmt.Errorf("failed to open file %q; error: %w", opts.rulesPath, err)
+		}
+		defer file.Close()
+
+		if rules, err = lint.ReadRules(file); err != nil {
+			return err
+		}
+	}
+
+	if opts.minContrastSet {
+		rules.MinContrast = opts.minContrast
+	}
+
+	if opts.minDistanceSet {
+		rules.MinDistance = opts.minDistance
+	}
+
+	labels, err := readLabels(opts)
+	if err != nil {
+		return err
+	}
+
+	violations, err := lint.Lint(labels, rules)
+	if err != nil {
+		return err
+	}
+
+	if opts.fix {
+		violations = fix(opts, violations)
+	}
+
+	if opts.format == "json" {
+		if violations == nil {
+			violations = []lint.Violation{}
+		}
+
+		enc := json.NewEncoder(opts.io.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(violations); err != nil {
+			return err
+		}
+	} else if err := writeTable(opts.io, violations); err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		names := make(map[string]bool)
+		for _, v := range violations {
+			names[v.Label] = true
+		}
+		return fmt.Errorf("found %d problem(s) with %d label(s)", len(violations), len(names))
+	}
+
+	return nil
+}
+
+func readLabels(opts *lintOptions) (github.Labels, error) {
+	if opts.path == "" {
+		labels, err := opts.client.ListLabels("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list labels; error: %w", err)
+		}
+
+		return labels, nil
+	}
+
+	format, err := github.SupportedInputFormat(path.Ext(opts.path))
+	if err != nil {
+		return nil, fmt.Errorf("%q has unsupported format, expected %v", opts.path, github.InputFormats())
+	}
+
+	file, err := utils.OpenFile(opts.fs, opts.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q; error: %w", opts.path, err)
+	}
+	defer file.Close()
+
+	labels, err := github.ReadLabels(github.OutputFormat(format), file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read labels; error: %w", err)
+	}
+
+	return labels, nil
+}
+
+// fix applies fixes and returns the violations that could not be fixed.
+func fix(opts *lintOptions, violations []lint.Violation) []lint.Violation {
+	failed := make(map[string]bool)
+	for _, edit := range lint.Fixes(violations) {
+		if _, err := opts.client.UpdateLabel(edit); err != nil {
+			failed[edit.Name] = true
+			fmt.Fprintf(opts.io.ErrOut, "Failed to fix label %q\n", edit.Name)
+			continue
+		}
+
+		if opts.io.IsStdoutTTY() {
+			fmt.Fprintf(opts.io.Out, "Fixed label '%s'\n", edit.Name)
+		}
+	}
+
+	remaining := violations[:0]
+	for _, v := range violations {
+		if v.Fix == nil || failed[v.Label] {
+			remaining = append(remaining, v)
+		}
+	}
+
+	return remaining
+}
+
+func writeTable(io *iostreams.IOStreams, violations []lint.Violation) error {
+	cs := io.ColorScheme()
+
+	if io.IsStdoutTTY() {
+		if len(violations) == 0 {
+			fmt.Fprintln(io.Out, "No problems found")
+			return nil
+		}
+
+		fmt.Fprintf(io.Out, "Found %d problem(s)\n\n", len(violations))
+	}
+
+	printer := cliutils.NewTablePrinter(io)
+	for _, v := range violations {
+		color := v.Color
+		printer.AddField(v.Label, nil, func(s string) string {
+			return cs.HexToRGB(color, s)
+		})
+		printer.AddField(v.Kind, nil, nil)
+		printer.AddField(v.Message, nil, cs.ColorFromString("gray"))
+		printer.EndRow()
+	}
+	return printer.Render()
+}
@@ -0,0 +1,270 @@
+package lint
+
+// cSpell:ignore fstest
+// cSpell:ignoreRegExp /[0-9A-Fa-f]{6}/
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+)
+
+func Test_lint(t *testing.T) {
+	type args struct {
+		stdout string
+		format string
+		tty    bool
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wantW string
+		wantE bool
+	}{
+		{
+			name: "no problems",
+			args: args{
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "documentation", "color": "0075ca"},
+					{"name": "enhancement", "color": "a2eeef"}
+				]}}}}`,
+				format: "table",
+			},
+		},
+		{
+			name: "no problems (TTY)",
+			args: args{
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "documentation", "color": "0075ca"},
+					{"name": "enhancement", "color": "a2eeef"}
+				]}}}}`,
+				format: "table",
+				tty:    true,
+			},
+			wantW: "No problems found\n",
+		},
+		{
+			name: "problems",
+			args: args{
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "bug", "color": "ff0000"},
+					{"name": "p1", "color": "fe0000"},
+					{"name": "p2", "color": "0075ca"}
+				]}}}}`,
+				format: "table",
+			},
+			wantW: heredoc.Docf(`bug%[1]scontrast%[1]scontrast ratio 4.00 with text #FFFFFF is less than 4.50
+			p1%[1]scontrast%[1]scontrast ratio 4.03 with text #FFFFFF is less than 4.50
+			bug%[1]ssimilar%[1]scolor difference 0.37 from "p1" with normal vision is less than 10.00
+			`, "\t"),
+			wantE: true,
+		},
+		{
+			name: "problems (JSON)",
+			args: args{
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "bug", "color": "d73a4a"},
+					{"name": "fixed", "color": "2da44e"}
+				]}}}}`,
+				format: "json",
+			},
+			wantW: heredoc.Doc(`[
+			  {
+			    "label": "bug",
+			    "color": "d73a4a",
+			    "other": "fixed",
+			    "kind": "similar",
+			    "vision": "deuteranopia",
+			    "value": 4.92,
+			    "message": "color difference 4.92 from \"fixed\" with deuteranopia vision is less than 10.00"
+			  }
+			]
+			`),
+			wantE: true,
+		},
+		{
+			name: "no problems (JSON)",
+			args: args{
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "documentation", "color": "0075ca"}
+				]}}}}`,
+				format: "json",
+			},
+			wantW: "[]\n",
+		},
+		{
+			name: "invalid color",
+			args: args{
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "invalid", "color": "red"}
+				]}}}}`,
+				format: "table",
+			},
+			wantW: heredoc.Docf(`invalid%[1]scolor%[1]sinvalid color "red": expected 6 hexadecimal digits, got "red"
+			`, "\t"),
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up output streams.
+			io, _, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(tt.args.tty)
+
+			// Set up gh output.
+			mock := &github.Mock{
+				Stdout: *bytes.NewBufferString(tt.args.stdout),
+			}
+
+			rootOpts := &options.GlobalOptions{}
+			opts := &lintOptions{
+				minContrast: 4.5,
+				minDistance: 10,
+				format:      tt.args.format,
+
+				client: github.New(mock),
+				io:     io,
+			}
+
+			if err := _lint(rootOpts, opts); (err != nil) != tt.wantE {
+				t.Errorf("_lint() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("_lint() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
+
+func Test_lint_rules(t *testing.T) {
+	rules := []byte(heredoc.Doc(`
+		lowercase: true
+		require-description: true
+		min-distance: 0
+	`))
+
+	type args struct {
+		path   string
+		fix    bool
+		tty    bool
+		stdout string
+		err    error
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantW   string
+		wantE   bool
+		wantErr string
+	}{
+		{
+			name: "file",
+			args: args{
+				path: "labels.csv",
+			},
+			wantW: heredoc.Docf(`Bug%[1]scase%[1]sname is not lowercase
+			Bug%[1]sdescription%[1]sdescription is required
+			`, "\t"),
+			wantE:   true,
+			wantErr: "found 2 problem(s) with 1 label(s)",
+		},
+		{
+			name: "relative file",
+			args: args{
+				path: "./labels.csv",
+			},
+			wantW: heredoc.Docf(`Bug%[1]scase%[1]sname is not lowercase
+			Bug%[1]sdescription%[1]sdescription is required
+			`, "\t"),
+			wantE: true,
+		},
+		{
+			name: "unsupported file",
+			args: args{
+				path: "labels.txt",
+			},
+			wantE: true,
+		},
+		{
+			name: "fix (TTY)",
+			args: args{
+				fix: true,
+				tty: true,
+				stdout: `{"data":{"repository":{"labels":{"nodes":[
+					{"name": "Bug", "color": "d73a4a", "description": "Something isn't working"}
+				]}}}}`,
+			},
+			wantW: heredoc.Doc(`Fixed label 'Bug'
+			No problems found
+			`),
+		},
+		{
+			name: "fix failed",
+			args: args{
+				fix: true,
+				err: errors.New("failed"),
+			},
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up output streams.
+			io, _, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(tt.args.tty)
+
+			// Set up gh output.
+			mock := &github.Mock{
+				Stdout: *bytes.NewBufferString(tt.args.stdout),
+				Err:    tt.args.err,
+			}
+
+			fs := fstest.MapFS{
+				"rules.yml": &fstest.MapFile{
+					Data: rules,
+				},
+				"labels.csv": &fstest.MapFile{
+					Data: []byte("Bug,d73a4a,,\ndocumentation,0075ca,Improvements or additions to documentation,\n"),
+				},
+				"labels.txt": &fstest.MapFile{},
+			}
+
+			rootOpts := &options.GlobalOptions{}
+			opts := &lintOptions{
+				path:      tt.args.path,
+				rulesPath: "rules.yml",
+				format:    "table",
+				fix:       tt.args.fix,
+
+				client: github.New(mock),
+				fs:     fs,
+				io:     io,
+			}
+
+			err := _lint(rootOpts, opts)
+			if (err != nil) != tt.wantE {
+				t.Errorf("_lint() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+			if tt.wantErr != "" && err.Error() != tt.wantErr {
+				t.Errorf("_lint() error = %q, want %q", err, tt.wantErr)
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("_lint() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -46,6 +46,7 @@ func list(globalOpts *options.GlobalOptions, opts *listOptions) error {
 		cli := &github.Cli{
 			Owner: owner,
 			Repo:  repo,
+			Host:  globalOpts.Host(),
 		}
 		opts.client = github.New(cli)
 	}
@@ -0,0 +1,209 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	cliutils "github.com/cli/cli/utils"
+	"github.com/heaths/gh-label/internal/analysis"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/spf13/cobra"
+)
+
+const table = "table"
+
+type statsOptions struct {
+	label  string
+	format string
+
+	// test
+	client *github.Client
+	io     *iostreams.IOStreams
+}
+
+func StatsCmd(globalOpts *options.GlobalOptions) *cobra.Command {
+	opts := &statsOptions{}
+	cmd := &cobra.Command{
+		Use:   "stats [name]",
+		Short: "Report how labels are used, optionally matching substring [name] in the label name or description",
+		Long: heredoc.Doc(`
+			Report how each label is used, optionally matching substring [name] in the label name or description.
+
+			For each label, the report counts open and closed issues and pull requests carrying it, shows when it was
+			first and last applied according to issue events, the median days to close issues carrying it, and which
+			other labels were applied to the same issues and pull requests most often.
+
+			Labels used rarely or only with another label may be candidates to retire.
+		`),
+		Example: heredoc.Doc(`
+			$ gh label stats
+			$ gh label stats --format csv > stats.csv
+		`),
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			format := strings.ToLower(opts.format)
+			switch format {
+			case table, string(github.CSV), string(github.JSON), string(github.TSV):
+				opts.format = format
+				return nil
+			}
+
+			return fmt.Errorf(`invalid flag "format": expected one of [csv json table tsv], got %q`, opts.format)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				opts.label = args[0]
+			}
+
+			return stats(globalOpts, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "", table, "Format of the report. One of [csv json table tsv].")
+
+	return cmd
+}
+
+func stats(globalOpts *options.GlobalOptions, opts *statsOptions) error {
+	if opts.client == nil {
+		owner, repo := globalOpts.Repo()
+		cli := &github.Cli{
+			Owner: owner,
+			Repo:  repo,
+			Host:  globalOpts.Host(),
+		}
+		opts.client = github.New(cli)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	labels, err := opts.client.ListLabels(opts.label)
+	if err != nil {
+		return fmt.Errorf("failed to list labels; error: %w", err)
+	}
+
+	issues, err := opts.client.ListIssues("all")
+	if err != nil {
+		return fmt.Errorf("failed to list issues; error: %w", err)
+	}
+
+	events, err := opts.client.ListLabelEvents()
+	if err != nil {
+		return fmt.Errorf("failed to list issue events; error: %w", err)
+	}
+
+	stats := analysis.ComputeStats(labels, issues, events)
+
+	if opts.format != table {
+		return write(opts, labels, stats)
+	}
+
+	io := opts.io
+	cs := io.ColorScheme()
+
+	if io.IsStdoutTTY() {
+		fmt.Fprintf(io.Out, "Showing %d labels\n\n", len(stats))
+	}
+
+	printer := cliutils.NewTablePrinter(io)
+	if printer.IsTTY() {
+		for _, header := range []string{"NAME", "ISSUES", "PRS", "FIRST APPLIED", "LAST APPLIED", "DAYS TO CLOSE", "APPLIED WITH"} {
+			printer.AddField(header, nil, cs.Bold)
+		}
+		printer.EndRow()
+	}
+
+	for _, s := range stats {
+		color := s.Label.Color
+		printer.AddField(s.Label.Name, nil, func(str string) string {
+			return cs.HexToRGB(color, str)
+		})
+		printer.AddField(fmt.Sprintf("%d open, %d closed", s.OpenIssues, s.ClosedIssues), nil, nil)
+		printer.AddField(fmt.Sprintf("%d open, %d closed", s.OpenPullRequests, s.ClosedPullRequests), nil, nil)
+		printer.AddField(formatTime(s.FirstApplied, printer.IsTTY()), nil, nil)
+		printer.AddField(formatTime(s.LastApplied, printer.IsTTY()), nil, nil)
+
+		days := ""
+		if s.ClosedIssues > 0 {
+			days = strconv.FormatFloat(medianDays(s), 'f', -1, 64)
+		}
+		printer.AddField(days, nil, nil)
+		printer.AddField(s.CoOccurrences.String(), nil, cs.ColorFromString("gray"))
+		printer.EndRow()
+	}
+
+	return printer.Render()
+}
+
+// write writes the report using the label writers with additional columns for stats.
+func write(opts *statsOptions, labels github.Labels, stats []analysis.Stats) error {
+	byName := make(map[string]analysis.Stats, len(stats))
+	for _, s := range stats {
+		byName[s.Label.Name] = s
+	}
+
+	column := func(name string, value func(analysis.Stats) interface{}) github.Column {
+		return github.Column{
+			Name: name,
+			Value: func(label github.Label) interface{} {
+				return value(byName[label.Name])
+			},
+		}
+	}
+
+	timeOrNil := func(t time.Time) interface{} {
+		if t.IsZero() {
+			return nil
+		}
+		return t
+	}
+
+	formatOpts := github.FormatOptions{
+		Fields: []string{"name", "color", "description"},
+		Columns: []github.Column{
+			column("openIssues", func(s analysis.Stats) interface{} { return s.OpenIssues }),
+			column("closedIssues", func(s analysis.Stats) interface{} { return s.ClosedIssues }),
+			column("openPullRequests", func(s analysis.Stats) interface{} { return s.OpenPullRequests }),
+			column("closedPullRequests", func(s analysis.Stats) interface{} { return s.ClosedPullRequests }),
+			column("firstApplied", func(s analysis.Stats) interface{} { return timeOrNil(s.FirstApplied) }),
+			column("lastApplied", func(s analysis.Stats) interface{} { return timeOrNil(s.LastApplied) }),
+			column("medianDaysToClose", func(s analysis.Stats) interface{} {
+				if s.ClosedIssues == 0 {
+					return nil
+				}
+				return medianDays(s)
+			}),
+			column("appliedWith", func(s analysis.Stats) interface{} {
+				if len(s.CoOccurrences) == 0 {
+					return nil
+				}
+				return s.CoOccurrences
+			}),
+		},
+	}
+
+	return labels.WriteWith(github.OutputFormat(opts.format), opts.io.Out, formatOpts)
+}
+
+// medianDays returns the median time to close issues in days rounded to one decimal place.
+func medianDays(s analysis.Stats) float64 {
+	return math.Round(s.MedianTimeToClose.Hours()/24*10) / 10
+}
+
+func formatTime(t time.Time, tty bool) string {
+	if t.IsZero() {
+		return ""
+	}
+	if tty {
+		return t.Local().Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
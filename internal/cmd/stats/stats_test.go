@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+)
+
+// statsMock returns labels, issues, and issue events.
+type statsMock struct {
+	github.Mock
+}
+
+func (m *statsMock) ListLabels(substr string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`{"data":{"repository":{"labels":{"nodes":[
+		{"name": "bug", "color": "d73a4a", "description": "Something isn't working"},
+		{"name": "p1", "color": "b60205"},
+		{"name": "wontfix", "color": "ffffff"}
+	]}}}}`), nil
+}
+
+func (m *statsMock) ListIssues(state string) (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`[
+		{"number": 1, "state": "closed", "created_at": "2026-10-01T00:00:00Z", "closed_at": "2026-10-02T12:00:00Z", "labels": [{"name": "bug"}, {"name": "p1"}]},
+		{"number": 2, "state": "open", "created_at": "2026-10-01T00:00:00Z", "labels": [{"name": "bug"}]},
+		{"number": 3, "state": "open", "created_at": "2026-10-01T00:00:00Z", "pull_request": {}, "labels": [{"name": "bug"}]}
+	]`), nil
+}
+
+func (m *statsMock) ListIssueEvents() (bytes.Buffer, error) {
+	return *bytes.NewBufferString(`[
+		{"event": "labeled", "created_at": "2026-10-03T00:00:00Z", "issue": {"number": 2}, "label": {"name": "bug"}},
+		{"event": "labeled", "created_at": "2026-10-01T00:00:00Z", "issue": {"number": 1}, "label": {"name": "bug"}}
+	]`), nil
+}
+
+func Test_stats(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		wantW  string
+	}{
+		{
+			name:   "table",
+			format: table,
+			wantW: heredoc.Docf(`
+				bug%[1]s1 open, 1 closed%[1]s1 open, 0 closed%[1]s2026-10-01T00:00:00Z%[1]s2026-10-03T00:00:00Z%[1]s1.5%[1]sp1 (1)
+				p1%[1]s0 open, 1 closed%[1]s0 open, 0 closed%[1]s%[1]s%[1]s1.5%[1]sbug (1)
+				wontfix%[1]s0 open, 0 closed%[1]s0 open, 0 closed%[1]s%[1]s%[1]s%[1]s
+			`, "\t"),
+		},
+		{
+			name:   "csv",
+			format: "csv",
+			wantW: heredoc.Doc(`
+				name,color,description,openIssues,closedIssues,openPullRequests,closedPullRequests,firstApplied,lastApplied,medianDaysToClose,appliedWith
+				bug,d73a4a,Something isn't working,1,1,1,0,2026-10-01T00:00:00Z,2026-10-03T00:00:00Z,1.5,p1 (1)
+				p1,b60205,,0,1,0,0,,,1.5,bug (1)
+				wontfix,ffffff,,0,0,0,0,,,,
+			`),
+		},
+		{
+			name:   "json",
+			format: "json",
+			wantW: heredoc.Doc(`
+				[
+				  {
+				    "name": "bug",
+				    "color": "d73a4a",
+				    "description": "Something isn't working",
+				    "openIssues": 1,
+				    "closedIssues": 1,
+				    "openPullRequests": 1,
+				    "closedPullRequests": 0,
+				    "firstApplied": "2026-10-01T00:00:00Z",
+				    "lastApplied": "2026-10-03T00:00:00Z",
+				    "medianDaysToClose": 1.5,
+				    "appliedWith": [{"label":"p1","count":1}]
+				  },
+				  {
+				    "name": "p1",
+				    "color": "b60205",
+				    "openIssues": 0,
+				    "closedIssues": 1,
+				    "openPullRequests": 0,
+				    "closedPullRequests": 0,
+				    "medianDaysToClose": 1.5,
+				    "appliedWith": [{"label":"bug","count":1}]
+				  },
+				  {
+				    "name": "wontfix",
+				    "color": "ffffff",
+				    "openIssues": 0,
+				    "closedIssues": 0,
+				    "openPullRequests": 0,
+				    "closedPullRequests": 0
+				  }
+				]
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, _, stdout, _ := iostreams.Test()
+
+			opts := &statsOptions{
+				format: tt.format,
+				client: github.New(&statsMock{}),
+				io:     io,
+			}
+			if err := stats(&options.GlobalOptions{}, opts); err != nil {
+				t.Errorf("stats() error = %v", err)
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("stats() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
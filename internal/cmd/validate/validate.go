@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/options"
+	"github.com/heaths/gh-label/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type validateOptions struct {
+	path      string
+	format    string
+	delimiter string
+
+	// test
+	fs fs.FS
+	io *iostreams.IOStreams
+}
+
+// opts are validate options available for testing.
+var opts *validateOptions
+
+func ValidateCmd() *cobra.Command {
+	opts = &validateOptions{}
+	cmd := &cobra.Command{
+		Use:   "validate <path>",
+		Short: `Validate labels in <path>, or stdin if <path> is "-", without importing them.`,
+		Example: heredoc.Doc(`
+			$ gh label validate ./labels.csv
+			$ gh label validate ./labels.json
+			$ gh label validate --format csv -
+		`),
+		Annotations: map[string]string{
+			options.OfflineAnnotation: "true",
+		},
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.path = args[0]
+			if opts.path != "-" {
+				if opts.format == "" {
+					opts.format = path.Ext(opts.path)
+				}
+			} else if opts.format == "" {
+				return fmt.Errorf(`--format is required when <path> is "-"`)
+			}
+
+			if format, err := github.SupportedInputFormat(opts.format); err != nil {
+				return fmt.Errorf("%q has unsupported format %q, expected %v", opts.path, opts.format, github.InputFormats())
+			} else {
+				opts.format = format
+			}
+
+			if opts.delimiter != "" && opts.format != string(github.CSV) && opts.format != string(github.TSV) {
+				return fmt.Errorf(`flag "delimiter" is not supported for format %q`, opts.format)
+			}
+
+			return validate(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "", "", fmt.Sprintf("Format of the input to parse. One of %v. The default is the file extension.", github.InputFormats()))
+	cmd.Flags().StringVarP(&opts.delimiter, "delimiter", "", "", `Field delimiter for csv or tsv formats, like ";" or "\t". The default is "," for csv and a tab for tsv.`)
+
+	return cmd
+}
+
+func validate(opts *validateOptions) error {
+	if opts.fs == nil {
+		pwd, err := os.Getwd()
+		if err != nil {
+			pwd = "/"
+		}
+		opts.fs = os.DirFS(pwd)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	formatOpts := github.FormatOptions{}
+	if opts.delimiter != "" {
+		delimiter, err := github.ParseDelimiter(opts.delimiter)
+		if err != nil {
+			return fmt.Errorf(`invalid flag "delimiter": %s`, err)
+		}
+		formatOpts.Delimiter = delimiter
+	}
+
+	var r io.Reader
+	if opts.path == "-" {
+		r = opts.io.In
+	} else {
+		if file, err := utils.OpenFile(opts.fs, opts.path); err != nil {
+			return fmt.Errorf("failed to open file %q; error: %w", opts.path, err)
+		} else {
+			r = file
+			defer file.Close()
+		}
+	}
+
+	labels, problems, err := github.ValidateLabels(github.OutputFormat(opts.format), r, formatOpts)
+	if err != nil {
+		return fmt.Errorf("failed to read labels; error: %w", err)
+	}
+
+	if len(problems) == 0 {
+		if opts.io.IsStdoutTTY() {
+			fmt.Fprintf(opts.io.Out, "Validated %d label(s) in %q\n", len(labels), opts.path)
+		}
+		return nil
+	}
+
+	if opts.io.IsStdoutTTY() {
+		fmt.Fprintf(opts.io.Out, "Found %d problem(s) in %q\n\n", len(problems), opts.path)
+	}
+
+	for _, problem := range problems {
+		fmt.Fprintf(opts.io.Out, "%s:%s\n", opts.path, problem)
+	}
+
+	return fmt.Errorf("found %d problem(s) in %q", len(problems), opts.path)
+}
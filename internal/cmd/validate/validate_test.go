@@ -0,0 +1,205 @@
+package validate
+
+// cSpell:ignore fstest notacolor
+
+import (
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+)
+
+func Test_ValidateCmd(t *testing.T) {
+	type want struct {
+		path   string
+		format string
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		want  want
+		wantE bool
+	}{
+		{
+			name: "csv file",
+			args: []string{"labels.csv"},
+			want: want{
+				path:   "labels.csv",
+				format: "csv",
+			},
+		},
+		{
+			name: "json override",
+			args: []string{"labels.txt", "--format", "json"},
+			want: want{
+				path:   "labels.txt",
+				format: "json",
+			},
+		},
+		{
+			name: "stream",
+			args: []string{"-", "--format", "csv"},
+			want: want{
+				path:   "-",
+				format: "csv",
+			},
+		},
+		{
+			name:  "stream without format",
+			args:  []string{"-"},
+			wantE: true,
+		},
+		{
+			name:  "unsupported format",
+			args:  []string{"labels.txt"},
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := ValidateCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			opts.fs = fstest.MapFS{
+				"labels.csv": &fstest.MapFile{},
+				"labels.txt": &fstest.MapFile{
+					Data: []byte("[]"),
+				},
+			}
+			opts.io, _, _, _ = iostreams.Test()
+
+			if err := cmd.Execute(); (err != nil) != tt.wantE {
+				t.Errorf("ValidateCmd().Execute() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if tt.wantE {
+				return
+			}
+
+			if opts.path != tt.want.path {
+				t.Errorf("ValidateCmd() path = %q, expected %q", opts.path, tt.want.path)
+			}
+
+			if opts.format != tt.want.format {
+				t.Errorf("ValidateCmd() format = %q, expected %q", opts.format, tt.want.format)
+			}
+		})
+	}
+}
+
+func Test_validate(t *testing.T) {
+	type args struct {
+		path   string
+		format string
+		stdin  string
+		tty    bool
+	}
+
+	fs := fstest.MapFS{
+		"labels.csv": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`name,color,description,url
+				bug,notacolor,Something isn't working,
+				bug,d73a4a,,
+			`)),
+		},
+		"labels.json": &fstest.MapFile{
+			Data: []byte(`[{"name": "bug", "color": "d73a4a"}]`),
+		},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wantW string
+		wantE bool
+	}{
+		{
+			name: "valid",
+			args: args{
+				path:   "labels.json",
+				format: "json",
+			},
+		},
+		{
+			name: "valid (TTY)",
+			args: args{
+				path:   "labels.json",
+				format: "json",
+				tty:    true,
+			},
+			wantW: "Validated 1 label(s) in \"labels.json\"\n",
+		},
+		{
+			name: "relative path",
+			args: args{
+				path:   "./labels.json",
+				format: "json",
+			},
+		},
+		{
+			name: "invalid",
+			args: args{
+				path:   "labels.csv",
+				format: "csv",
+			},
+			wantW: heredoc.Doc(`labels.csv:2:5: color: invalid color "notacolor": colors must include 6 hexadecimal digits for RGB with optional "#" prefix, 3 hexadecimal digits with "#" prefix, a color name, rgb(), or hsl()
+			labels.csv:3:1: name: duplicate name "bug" first defined on line 2
+			`),
+			wantE: true,
+		},
+		{
+			name: "invalid stream (TTY)",
+			args: args{
+				path:   "-",
+				format: "json",
+				stdin:  `[{"name": ""}]`,
+				tty:    true,
+			},
+			wantW: heredoc.Doc(`Found 1 problem(s) in "-"
+
+			-:1:11: [0].name: name is required
+			`),
+			wantE: true,
+		},
+		{
+			name: "missing file",
+			args: args{
+				path:   "missing.csv",
+				format: "csv",
+			},
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, stdout, _ := iostreams.Test()
+			io.SetStdoutTTY(tt.args.tty)
+			stdin.WriteString(tt.args.stdin)
+
+			opts := &validateOptions{
+				path:   tt.args.path,
+				format: tt.args.format,
+
+				fs: fs,
+				io: io,
+			}
+
+			if err := validate(opts); (err != nil) != tt.wantE {
+				t.Errorf("validate() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if gotW := stdout.String(); gotW != tt.wantW {
+				t.Errorf("validate() = %q, want %q", gotW, tt.wantW)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/safeexec"
+)
+
+// Cli runs git in a local clone.
+type Cli struct {
+	// Dir is the working directory. The default is the current directory.
+	Dir string
+}
+
+// Show gets the contents of the file at path relative to the working directory at rev, or HEAD if rev is empty.
+// A rev starting with "-" is an error so that it cannot be interpreted as an option.
+func (cli *Cli) Show(rev, file string) ([]byte, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+
+	if strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid revision %q", rev)
+	}
+
+	stdout, err := cli.run("show", "--end-of-options", fmt.Sprintf("%s:./%s", rev, path.Clean(filepath.ToSlash(file))))
+	if err != nil {
+		return nil, err
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// Remote is a git remote.
+type Remote struct {
+	Name string
+	URL  string
+
+	// Resolved is set by "gh repo set-default" to "base" or "OWNER/REPO", if at all.
+	Resolved string
+}
+
+// Repo returns the host, owner, and name of the repository from the remote URL.
+// It returns false if the URL is not like "https://HOST/OWNER/REPO" or "git@HOST:OWNER/REPO".
+func (r Remote) Repo() (host, owner, repo string, ok bool) {
+	var p string
+	if u, err := url.Parse(r.URL); err == nil && u.Scheme != "" && u.Host != "" {
+		host, p = u.Hostname(), u.Path
+	} else if i := strings.Index(r.URL, ":"); i > 0 && !strings.Contains(r.URL[:i], "/") {
+		// scp-like syntax: [user@]host:path
+		host, p = r.URL[:i], r.URL[i+1:]
+		if j := strings.LastIndex(host, "@"); j >= 0 {
+			host = host[j+1:]
+		}
+	} else {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return
+	}
+
+	return strings.ToLower(host), parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+// Remotes gets remotes in the order they are configured.
+func (cli *Cli) Remotes() ([]Remote, error) {
+	stdout, err := cli.run("config", "--get-regexp", `^remote\..*\.(url|gh-resolved)$`)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			// No remotes are configured.
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var remotes []Remote
+	index := make(map[string]int)
+
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		key, value := strings.TrimPrefix(fields[0], "remote."), fields[1]
+
+		var name string
+		if strings.HasSuffix(key, ".url") {
+			name = strings.TrimSuffix(key, ".url")
+		} else {
+			name = strings.TrimSuffix(key, ".gh-resolved")
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(remotes)
+			index[name] = i
+			remotes = append(remotes, Remote{Name: name})
+		}
+
+		if strings.HasSuffix(key, ".url") {
+			remotes[i].URL = value
+		} else {
+			remotes[i].Resolved = value
+		}
+	}
+
+	return remotes, nil
+}
+
+func (cli *Cli) run(args ...string) (stdout bytes.Buffer, err error) {
+	bin, err := safeexec.LookPath("git")
+	if err != nil {
+		err = fmt.Errorf("cannot find git; is it installed? error: %w", err)
+		return
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.Command(bin, args...)
+	cmd.Dir = cli.Dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		err = fmt.Errorf("git returned error: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+		return
+	}
+
+	return
+}
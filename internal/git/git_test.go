@@ -0,0 +1,14 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestCli_Show_invalidRev(t *testing.T) {
+	cli := &Cli{Dir: t.TempDir()}
+	for _, rev := range []string{"-p", "--output=/tmp/labels.csv"} {
+		if _, err := cli.Show(rev, "labels.csv"); err == nil || err.Error() != `invalid revision "`+rev+`"` {
+			t.Errorf("Show(%q) error = %v, expected invalid revision", rev, err)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package github
 import (
 	"bytes"
 	"fmt"
+	"net/url"
 	"os/exec"
+	"strings"
 
 	"github.com/cli/safeexec"
 )
@@ -11,6 +13,10 @@ import (
 type Cli struct {
 	Owner string
 	Repo  string
+
+	// Host is the GitHub hostname like "github.com" or a GitHub Enterprise Server hostname.
+	// The default is the host gh is authenticated to, or GH_HOST.
+	Host string
 }
 
 func (cli *Cli) CreateLabel(label Label) (bytes.Buffer, error) {
@@ -27,7 +33,7 @@ func (cli *Cli) CreateLabel(label Label) (bytes.Buffer, error) {
 		args = append(args, "-F", fmt.Sprintf("description=%s", label.Description))
 	}
 
-	stdout, _, err := run(args...)
+	stdout, _, err := cli.run(args...)
 	if err != nil {
 		return bytes.Buffer{}, err
 	}
@@ -61,7 +67,79 @@ func (cli *Cli) ListLabels(substr string) (bytes.Buffer, error) {
 		"-f", fmt.Sprintf("query=%s", query),
 	}
 
-	stdout, _, err := run(args...)
+	stdout, _, err := cli.run(args...)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return stdout, nil
+}
+
+func (cli *Cli) GetContents(owner, repo, path, ref string) (bytes.Buffer, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	args := []string{
+		fmt.Sprintf("/repos/%s/%s/contents/%s", url.PathEscape(owner), url.PathEscape(repo), strings.Join(segments, "/")),
+		"-X", "GET",
+	}
+
+	if ref != "" {
+		args = append(args, "-f", fmt.Sprintf("ref=%s", ref))
+	}
+
+	stdout, _, err := cli.run(args...)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return stdout, nil
+}
+
+func (cli *Cli) GetRepository() (bytes.Buffer, error) {
+	args := []string{
+		fmt.Sprintf("/repos/%s/%s", cli.Owner, cli.Repo),
+		// Topics are only returned in this preview for older versions of GitHub Enterprise Server.
+		"-H", "accept:application/vnd.github.mercy-preview+json",
+	}
+
+	stdout, _, err := cli.run(args...)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return stdout, nil
+}
+
+func (cli *Cli) SearchRepositories(query string) (bytes.Buffer, error) {
+	args := []string{
+		"/search/repositories",
+		"-X", "GET",
+		"--paginate",
+		"-f", fmt.Sprintf("q=%s", query),
+		"-f", "per_page=100",
+	}
+
+	stdout, _, err := cli.run(args...)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return stdout, nil
+}
+
+func (cli *Cli) SearchIssues(query string) (bytes.Buffer, error) {
+	args := []string{
+		"/search/issues",
+		"-X", "GET",
+		"--paginate",
+		"-f", fmt.Sprintf("q=repo:%s/%s %s", cli.Owner, cli.Repo, query),
+		"-f", "per_page=100",
+	}
+
+	stdout, _, err := cli.run(args...)
 	if err != nil {
 		return bytes.Buffer{}, err
 	}
@@ -69,6 +147,73 @@ func (cli *Cli) ListLabels(substr string) (bytes.Buffer, error) {
 	return stdout, nil
 }
 
+func (cli *Cli) GetIssue(number int) (bytes.Buffer, error) {
+	return cli.get(fmt.Sprintf("issues/%d", number))
+}
+
+func (cli *Cli) ListIssues(state string) (bytes.Buffer, error) {
+	return cli.get("issues", "--paginate", "-f", fmt.Sprintf("state=%s", state), "-f", "per_page=100")
+}
+
+func (cli *Cli) GetPullRequest(number int) (bytes.Buffer, error) {
+	return cli.get(fmt.Sprintf("pulls/%d", number))
+}
+
+func (cli *Cli) ListPullRequestFiles(number int) (bytes.Buffer, error) {
+	return cli.get(fmt.Sprintf("pulls/%d/files", number), "--paginate", "-f", "per_page=100")
+}
+
+func (cli *Cli) ListIssueEvents() (bytes.Buffer, error) {
+	return cli.get("issues/events", "--paginate", "-f", "per_page=100")
+}
+
+// get gets a resource at path relative to the repository with optional arguments.
+func (cli *Cli) get(path string, args ...string) (bytes.Buffer, error) {
+	args = append([]string{
+		fmt.Sprintf("/repos/%s/%s/%s", cli.Owner, cli.Repo, path),
+		"-X", "GET",
+	}, args...)
+
+	stdout, _, err := cli.run(args...)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return stdout, nil
+}
+
+func (cli *Cli) AddIssueLabels(number int, names []string) (bytes.Buffer, error) {
+	args := []string{
+		fmt.Sprintf("/repos/:owner/:repo/issues/%d/labels", number),
+		"-X", "POST",
+		"-F", fmt.Sprintf("owner=%s", cli.Owner),
+		"-F", fmt.Sprintf("repo=%s", cli.Repo),
+	}
+
+	for _, name := range names {
+		args = append(args, "-f", fmt.Sprintf("labels[]=%s", name))
+	}
+
+	stdout, _, err := cli.run(args...)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return stdout, nil
+}
+
+func (cli *Cli) RemoveIssueLabel(number int, name string) error {
+	args := []string{
+		fmt.Sprintf("/repos/:owner/:repo/issues/%d/labels/%s", number, url.PathEscape(name)),
+		"-X", "DELETE",
+		"-F", fmt.Sprintf("owner=%s", cli.Owner),
+		"-F", fmt.Sprintf("repo=%s", cli.Repo),
+	}
+
+	_, _, err := cli.run(args...)
+	return err
+}
+
 func (cli *Cli) DeleteLabel(name string) error {
 	args := []string{
 		fmt.Sprintf("/repos/:owner/:repo/labels/%s", name),
@@ -77,7 +222,7 @@ func (cli *Cli) DeleteLabel(name string) error {
 		"-F", fmt.Sprintf("repo=%s", cli.Repo),
 	}
 
-	_, _, err := run(args...)
+	_, _, err := cli.run(args...)
 	return err
 }
 
@@ -101,7 +246,7 @@ func (cli *Cli) UpdateLabel(label EditLabel) (bytes.Buffer, error) {
 		args = append(args, "-F", fmt.Sprintf("new_name=%s", label.NewName))
 	}
 
-	stdout, _, err := run(args...)
+	stdout, _, err := cli.run(args...)
 	if err != nil {
 		return bytes.Buffer{}, err
 	}
@@ -109,7 +254,7 @@ func (cli *Cli) UpdateLabel(label EditLabel) (bytes.Buffer, error) {
 	return stdout, nil
 }
 
-func run(args ...string) (stdout, stderr bytes.Buffer, err error) {
+func (cli *Cli) run(args ...string) (stdout, stderr bytes.Buffer, err error) {
 	bin, err := safeexec.LookPath("gh")
 	if err != nil {
 		err = fmt.Errorf("cannot find gh; is it installed? error: %w", err)
@@ -118,6 +263,9 @@ func run(args ...string) (stdout, stderr bytes.Buffer, err error) {
 
 	// Always prepend arguments passed to every command.
 	args = append([]string{"api", "-H", "accept:application/vnd.github.v3+json"}, args...)
+	if cli.Host != "" {
+		args = append(args, "--hostname", cli.Host)
+	}
 
 	cmd := exec.Command(bin, args...)
 	cmd.Stdout = &stdout
@@ -2,7 +2,9 @@ package github
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -13,7 +15,11 @@ type EditLabel struct {
 }
 
 type Client struct {
-	labels LabelsService
+	labels     LabelsService
+	contents   ContentsService
+	repository RepositoryService
+	search     SearchService
+	issues     IssuesService
 }
 
 type LabelsService interface {
@@ -21,6 +27,43 @@ type LabelsService interface {
 	DeleteLabel(name string) error
 	ListLabels(substr string) (bytes.Buffer, error)
 	UpdateLabel(label EditLabel) (bytes.Buffer, error)
+
+	AddIssueLabels(number int, names []string) (bytes.Buffer, error)
+	RemoveIssueLabel(number int, name string) error
+}
+
+// ContentsService gets files from any repository.
+type ContentsService interface {
+	GetContents(owner, repo, path, ref string) (bytes.Buffer, error)
+}
+
+// RepositoryService gets information about the repository.
+type RepositoryService interface {
+	GetRepository() (bytes.Buffer, error)
+}
+
+// SearchService searches across repositories.
+type SearchService interface {
+	SearchRepositories(query string) (bytes.Buffer, error)
+	SearchIssues(query string) (bytes.Buffer, error)
+}
+
+// IssuesService gets issues and pull requests in the repository.
+type IssuesService interface {
+	GetIssue(number int) (bytes.Buffer, error)
+	ListIssues(state string) (bytes.Buffer, error)
+	GetPullRequest(number int) (bytes.Buffer, error)
+	ListPullRequestFiles(number int) (bytes.Buffer, error)
+	ListIssueEvents() (bytes.Buffer, error)
+}
+
+// Repository describes attributes of a repository.
+type Repository struct {
+	Owner      string
+	Name       string
+	Language   string
+	Visibility string
+	Topics     []string
 }
 
 func New(labels LabelsService) *Client {
@@ -31,8 +74,18 @@ func New(labels LabelsService) *Client {
 		}
 	}
 
+	// Services like Cli and Mock may implement other services as well.
+	contents, _ := labels.(ContentsService)
+	repository, _ := labels.(RepositoryService)
+	search, _ := labels.(SearchService)
+	issues, _ := labels.(IssuesService)
+
 	return &Client{
 		labels,
+		contents,
+		repository,
+		search,
+		issues,
 	}
 }
 
@@ -66,6 +119,86 @@ func (c *Client) DeleteLabel(name string) error {
 	return c.labels.DeleteLabel(name)
 }
 
+// GetContents gets the contents of the file at path in the repository owner/repo at ref,
+// or the default branch if ref is empty.
+func (c *Client) GetContents(owner, repo, path, ref string) ([]byte, error) {
+	if c.contents == nil {
+		return nil, errors.New("getting contents is not supported")
+	}
+
+	buf, err := c.contents.GetContents(owner, repo, path, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		Type     string
+		Encoding string
+		Content  string
+	}
+	if err = json.Unmarshal(buf.Bytes(), &file); err != nil {
+		return nil, fmt.Errorf("failed to read contents of %q; error: %w", path, err)
+	}
+
+	if file.Type != "file" {
+		return nil, fmt.Errorf("%q is not a file", path)
+	}
+
+	if file.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported encoding %q for %q", file.Encoding, path)
+	}
+
+	// Content is wrapped across lines.
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contents of %q; error: %w", path, err)
+	}
+
+	return data, nil
+}
+
+// GetRepository gets attributes of the repository.
+func (c *Client) GetRepository() (Repository, error) {
+	if c.repository == nil {
+		return Repository{}, errors.New("getting the repository is not supported")
+	}
+
+	buf, err := c.repository.GetRepository()
+	if err != nil {
+		return Repository{}, err
+	}
+
+	var resp struct {
+		Name  string
+		Owner struct {
+			Login string
+		}
+		Language   string
+		Private    bool
+		Visibility string
+		Topics     []string
+	}
+	if err = json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		return Repository{}, fmt.Errorf("failed to read repository; error: %w, data: %s", err, buf.String())
+	}
+
+	// Older versions of GitHub Enterprise Server do not return visibility.
+	if resp.Visibility == "" {
+		resp.Visibility = "public"
+		if resp.Private {
+			resp.Visibility = "private"
+		}
+	}
+
+	return Repository{
+		Owner:      resp.Owner.Login,
+		Name:       resp.Name,
+		Language:   resp.Language,
+		Visibility: resp.Visibility,
+		Topics:     resp.Topics,
+	}, nil
+}
+
 func (c *Client) ListLabels(substr string) (Labels, error) {
 	buf, err := c.labels.ListLabels(substr)
 	if err != nil {
@@ -115,15 +248,80 @@ func (c *Client) UpdateLabel(label EditLabel) (Label, error) {
 	return updated, nil
 }
 
+// SearchRepositories returns the full names like "OWNER/REPO" of repositories matching query.
+func (c *Client) SearchRepositories(query string) ([]string, error) {
+	if c.search == nil {
+		return nil, errors.New("searching repositories is not supported")
+	}
+
+	buf, err := c.search.SearchRepositories(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	// Paginated responses are concatenated.
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var resp struct {
+			Items []struct {
+				FullName string `json:"full_name"`
+			}
+		}
+		if err = dec.Decode(&resp); err != nil {
+			return nil, fmt.Errorf("failed to read repositories; error: %w", err)
+		}
+
+		for _, item := range resp.Items {
+			names = append(names, item.FullName)
+		}
+	}
+
+	return names, nil
+}
+
 type Mock struct {
 	Stdout bytes.Buffer
 	Err    error
 }
 
+func (m *Mock) AddIssueLabels(number int, names []string) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
 func (m *Mock) CreateLabel(label Label) (bytes.Buffer, error) {
 	return m.Stdout, m.Err
 }
 
+func (m *Mock) GetContents(owner, repo, path, ref string) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) GetIssue(number int) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) GetPullRequest(number int) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) GetRepository() (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) ListIssues(state string) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) ListPullRequestFiles(number int) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) ListIssueEvents() (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
 func (m *Mock) ListLabels(substr string) (bytes.Buffer, error) {
 	return m.Stdout, m.Err
 }
@@ -132,6 +330,18 @@ func (m *Mock) DeleteLabel(name string) error {
 	return m.Err
 }
 
+func (m *Mock) RemoveIssueLabel(number int, name string) error {
+	return m.Err
+}
+
+func (m *Mock) SearchIssues(query string) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
+func (m *Mock) SearchRepositories(query string) (bytes.Buffer, error) {
+	return m.Stdout, m.Err
+}
+
 func (m *Mock) UpdateLabel(label EditLabel) (bytes.Buffer, error) {
 	return m.Stdout, m.Err
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 )
@@ -111,6 +112,200 @@ func Test_DeleteLabel(t *testing.T) {
 	}
 }
 
+func Test_GetContents(t *testing.T) {
+	tests := []struct {
+		name     string
+		stdout   bytes.Buffer
+		err      error
+		contents ContentsService
+		want     string
+		wantE    bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name:   "deserialization error",
+			stdout: *bytes.NewBufferString("invalid JSON"),
+			wantE:  true,
+		},
+		{
+			name:   "directory",
+			stdout: *bytes.NewBufferString(`[{"type": "file", "name": "labels.csv"}]`),
+			wantE:  true,
+		},
+		{
+			name:   "submodule",
+			stdout: *bytes.NewBufferString(`{"type": "submodule"}`),
+			wantE:  true,
+		},
+		{
+			name:   "invalid content",
+			stdout: *bytes.NewBufferString(`{"type": "file", "encoding": "base64", "content": "!"}`),
+			wantE:  true,
+		},
+		{
+			name: "success",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`{
+				"type": "file",
+				"encoding": "base64",
+				"content": "bmFtZSxjb2xvcgpidWcs\nZDczYTRhCg==\n"
+			}`)),
+			want: "name,color\nbug,d73a4a\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Stdout: tt.stdout,
+				Err:    tt.err,
+			}
+			client := New(mock)
+			got, err := client.GetContents("heaths", ".github", "labels.csv", "main")
+			if (err != nil) != tt.wantE {
+				t.Errorf("GetContents() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("GetContents() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetContents_unsupported(t *testing.T) {
+	client := Client{
+		labels: &Mock{},
+	}
+	if _, err := client.GetContents("heaths", ".github", "labels.csv", ""); err == nil {
+		t.Errorf("GetContents() expected error")
+	}
+}
+
+func Test_GetRepository(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout bytes.Buffer
+		err    error
+		want   Repository
+		wantE  bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name:   "deserialization error",
+			stdout: *bytes.NewBufferString("invalid JSON"),
+			wantE:  true,
+		},
+		{
+			name: "success",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`{
+				"name": "gh-label",
+				"owner": {"login": "heaths"},
+				"language": "Go",
+				"private": false,
+				"visibility": "public",
+				"topics": ["cli", "gh-extension"]
+			}`)),
+			want: Repository{
+				Owner:      "heaths",
+				Name:       "gh-label",
+				Language:   "Go",
+				Visibility: "public",
+				Topics:     []string{"cli", "gh-extension"},
+			},
+		},
+		{
+			name: "private without visibility",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`{
+				"name": "gh-label",
+				"owner": {"login": "heaths"},
+				"language": null,
+				"private": true
+			}`)),
+			want: Repository{
+				Owner:      "heaths",
+				Name:       "gh-label",
+				Visibility: "private",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Stdout: tt.stdout,
+				Err:    tt.err,
+			}
+			client := New(mock)
+			got, err := client.GetRepository()
+			if (err != nil) != tt.wantE {
+				t.Errorf("GetRepository() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SearchRepositories(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout bytes.Buffer
+		err    error
+		want   []string
+		wantE  bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name:   "deserialization error",
+			stdout: *bytes.NewBufferString("invalid JSON"),
+			wantE:  true,
+		},
+		{
+			name: "multiple pages",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`{
+				"total_count": 3,
+				"items": [{"full_name": "heaths/gh-label"}, {"full_name": "heaths/gh-projects"}]
+			}{
+				"total_count": 3,
+				"items": [{"full_name": "heaths/go-console"}]
+			}`)),
+			want: []string{"heaths/gh-label", "heaths/gh-projects", "heaths/go-console"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Stdout: tt.stdout,
+				Err:    tt.err,
+			}
+			client := New(mock)
+			got, err := client.SearchRepositories("user:heaths")
+			if (err != nil) != tt.wantE {
+				t.Errorf("SearchRepositories() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchRepositories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_ListLabels(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -266,3 +461,360 @@ func Test_UpdateLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiURL string
+		want   string
+	}{
+		{
+			name:   "github.com",
+			apiURL: "https://api.github.com/repos/heaths/gh-label/labels/bug",
+			want:   "https://github.com/heaths/gh-label/labels/bug",
+		},
+		{
+			name:   "escaped",
+			apiURL: "https://api.github.com/repos/heaths/gh-label/labels/good%20first%20issue",
+			want:   "https://github.com/heaths/gh-label/labels/good%20first%20issue",
+		},
+		{
+			name:   "enterprise server",
+			apiURL: "https://ghe.example.com/api/v3/repos/heaths/gh-label/labels/bug",
+			want:   "https://ghe.example.com/heaths/gh-label/labels/bug",
+		},
+		{
+			name:   "web url",
+			apiURL: "https://github.com/heaths/gh-label/labels/bug",
+			want:   "https://github.com/heaths/gh-label/labels/bug",
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WebURL(tt.apiURL); got != tt.want {
+				t.Errorf("WebURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_AddIssueLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout bytes.Buffer
+		err    error
+		want   Labels
+		wantE  bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name:   "deserialization error",
+			stdout: *bytes.NewBufferString("invalid JSON"),
+			wantE:  true,
+		},
+		{
+			name: "success",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`[
+				{"name": "bug", "color": "d73a4a", "description": "Something isn't working"},
+				{"name": "triage", "color": "fbca04"}
+			]`)),
+			want: Labels{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+				{Name: "triage", Color: "fbca04"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Stdout: tt.stdout,
+				Err:    tt.err,
+			}
+			client := New(mock)
+			got, err := client.AddIssueLabels(1, []string{"triage"})
+			if (err != nil) != tt.wantE {
+				t.Errorf("AddIssueLabels() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddIssueLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_RemoveIssueLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		want  bool
+		wantE bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name: "not applied",
+			err:  errors.New("gh returned error: exit status 1, stderr: gh: Label does not exist (HTTP 404)"),
+		},
+		{
+			name:  "other error containing 404",
+			err:   errors.New("gh returned error: exit status 1, stderr: gh: Validation Failed for label '404' (HTTP 422)"),
+			wantE: true,
+		},
+		{
+			name: "success",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Err: tt.err,
+			}
+			client := New(mock)
+			got, err := client.RemoveIssueLabel(1, "triage")
+			if (err != nil) != tt.wantE {
+				t.Errorf("RemoveIssueLabel() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("RemoveIssueLabel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SearchIssues(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		stdout bytes.Buffer
+		err    error
+		want   []Issue
+		wantE  bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name:  "repo qualifier",
+			query: "is:open repo:cli/cli",
+			wantE: true,
+		},
+		{
+			name:  "negated org qualifier",
+			query: "is:open -ORG:cli",
+			wantE: true,
+		},
+		{
+			name:  "user qualifier",
+			query: "user:heaths",
+			wantE: true,
+		},
+		{
+			name:   "deserialization error",
+			stdout: *bytes.NewBufferString("invalid JSON"),
+			wantE:  true,
+		},
+		{
+			name: "multiple pages",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`{
+				"total_count": 2,
+				"items": [{
+					"number": 1,
+					"title": "Crash on import",
+					"html_url": "https://github.com/heaths/gh-label/issues/1",
+					"labels": [{"name": "bug", "color": "d73a4a"}]
+				}]
+			}{
+				"total_count": 2,
+				"items": [{
+					"number": 2,
+					"title": "Fix crash",
+					"html_url": "https://github.com/heaths/gh-label/pull/2",
+					"pull_request": {"url": "https://api.github.com/repos/heaths/gh-label/pulls/2"},
+					"labels": []
+				}]
+			}`)),
+			want: []Issue{
+				{
+					Number: 1,
+					Title:  "Crash on import",
+					URL:    "https://github.com/heaths/gh-label/issues/1",
+					Labels: Labels{{Name: "bug", Color: "d73a4a"}},
+				},
+				{
+					Number:      2,
+					Title:       "Fix crash",
+					URL:         "https://github.com/heaths/gh-label/pull/2",
+					PullRequest: true,
+					Labels:      Labels{},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Stdout: tt.stdout,
+				Err:    tt.err,
+			}
+			client := New(mock)
+			query := tt.query
+			if query == "" {
+				query = "is:open"
+			}
+			got, err := client.SearchIssues(query)
+			if (err != nil) != tt.wantE {
+				t.Errorf("SearchIssues() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchIssues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssue_InRepo(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want bool
+	}{
+		{
+			name: "issue",
+			url:  "https://github.com/heaths/gh-label/issues/1",
+			want: true,
+		},
+		{
+			name: "pull request with different case",
+			url:  "https://github.com/Heaths/GH-Label/pull/2",
+			want: true,
+		},
+		{
+			name: "enterprise",
+			url:  "https://github.example.com/heaths/gh-label/issues/3",
+			want: true,
+		},
+		{
+			name: "other repo",
+			url:  "https://github.com/cli/cli/issues/1",
+		},
+		{
+			name: "repo prefix",
+			url:  "https://github.com/heaths/gh-label-test/issues/1",
+		},
+		{
+			name: "repository URL",
+			url:  "https://github.com/heaths/gh-label",
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := Issue{URL: tt.url}
+			if got := issue.InRepo("heaths", "gh-label"); got != tt.want {
+				t.Errorf("InRepo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ListLabelEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout bytes.Buffer
+		err    error
+		want   []LabelEvent
+		wantE  bool
+	}{
+		{
+			name:  "gh error",
+			err:   errors.New("gh exited with code 1"),
+			wantE: true,
+		},
+		{
+			name:   "deserialization error",
+			stdout: *bytes.NewBufferString("invalid JSON"),
+			wantE:  true,
+		},
+		{
+			name: "multiple pages",
+			stdout: *bytes.NewBufferString(heredoc.Doc(`[
+				{
+					"event": "labeled",
+					"actor": {"login": "heaths"},
+					"created_at": "2026-10-01T12:00:00Z",
+					"issue": {"number": 1, "title": "Crash"},
+					"label": {"name": "bug", "color": "d73a4a"}
+				},
+				{
+					"event": "closed",
+					"actor": {"login": "heaths"},
+					"created_at": "2026-10-01T11:00:00Z",
+					"issue": {"number": 1, "title": "Crash"}
+				}
+			][
+				{
+					"event": "unlabeled",
+					"actor": null,
+					"created_at": "2026-09-30T12:00:00Z",
+					"issue": {"number": 2, "title": "Fix", "pull_request": {}},
+					"label": {"name": "wip", "color": "ededed"}
+				}
+			]`)),
+			want: []LabelEvent{
+				{
+					Event:     "labeled",
+					Actor:     "heaths",
+					CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+					Issue:     Issue{Number: 1, Title: "Crash"},
+					Label:     Label{Name: "bug", Color: "d73a4a"},
+				},
+				{
+					Event:     "unlabeled",
+					CreatedAt: time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC),
+					Issue:     Issue{Number: 2, Title: "Fix", PullRequest: true},
+					Label:     Label{Name: "wip", Color: "ededed"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &Mock{
+				Stdout: tt.stdout,
+				Err:    tt.err,
+			}
+			client := New(mock)
+			got, err := client.ListLabelEvents()
+			if (err != nil) != tt.wantE {
+				t.Errorf("ListLabelEvents() error = %v, want: %v", err, tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListLabelEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
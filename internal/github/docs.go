@@ -0,0 +1,122 @@
+package github
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/heaths/gh-label/internal/utils"
+)
+
+// unscoped is the heading for labels without a scope when grouped by scope.
+const unscoped = "Other"
+
+type labelGroup struct {
+	scope  string
+	labels Labels
+}
+
+// groups returns labels grouped by scope in order of first appearance, followed by labels without a scope.
+// If opts.GroupByScope is false, a single group without a scope is returned.
+func (labels *Labels) groups(opts FormatOptions) []labelGroup {
+	if !opts.GroupByScope {
+		return []labelGroup{{labels: *labels}}
+	}
+
+	separator := opts.ScopeSeparator
+	if separator == "" {
+		separator = ":"
+	}
+
+	var groups []labelGroup
+	var other Labels
+	index := make(map[string]int)
+
+	for _, label := range *labels {
+		i := strings.Index(label.Name, separator)
+		if i <= 0 {
+			other = append(other, label)
+			continue
+		}
+
+		scope := strings.TrimSpace(label.Name[:i])
+		if j, ok := index[scope]; ok {
+			groups[j].labels = append(groups[j].labels, label)
+		} else {
+			index[scope] = len(groups)
+			groups = append(groups, labelGroup{scope, Labels{label}})
+		}
+	}
+
+	if len(other) > 0 {
+		groups = append(groups, labelGroup{unscoped, other})
+	}
+
+	return groups
+}
+
+func (labels *Labels) writeMarkdown(w io.Writer, opts FormatOptions) error {
+	escape := strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ")
+
+	for i, group := range labels.groups(opts) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		if group.scope != "" {
+			fmt.Fprintf(w, "### %s\n\n", group.scope)
+		}
+
+		fmt.Fprintln(w, "| Color | Name | Description |")
+		fmt.Fprintln(w, "| --- | --- | --- |")
+
+		for _, label := range group.labels {
+			color := strings.TrimPrefix(label.Color, "#")
+			badge := fmt.Sprintf("https://img.shields.io/badge/-%s-%s", url.PathEscape(color), url.PathEscape(color))
+
+			if _, err := fmt.Fprintf(w, "| ![#%s](%s) | %s | %s |\n", color, badge, escape.Replace(label.Name), escape.Replace(label.Description)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (labels *Labels) writeHTML(w io.Writer, opts FormatOptions) error {
+	for i, group := range labels.groups(opts) {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		if group.scope != "" {
+			fmt.Fprintf(w, "<h3>%s</h3>\n", html.EscapeString(group.scope))
+		}
+
+		fmt.Fprintln(w, "<table>")
+		fmt.Fprintln(w, "  <thead>")
+		fmt.Fprintln(w, "    <tr><th>Color</th><th>Name</th><th>Description</th></tr>")
+		fmt.Fprintln(w, "  </thead>")
+		fmt.Fprintln(w, "  <tbody>")
+
+		for _, label := range group.labels {
+			color := strings.TrimPrefix(label.Color, "#")
+			text, err := utils.TextColor(color)
+			if err != nil {
+				return fmt.Errorf("invalid color for label %q; error: %w", label.Name, err)
+			}
+
+			swatch := fmt.Sprintf(`<span style="background-color:#%[1]s;color:#%[2]s;border-radius:2em;padding:0 7px;font-size:12px;font-weight:500">#%[1]s</span>`, html.EscapeString(color), text)
+			fmt.Fprintf(w, "    <tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", swatch, html.EscapeString(label.Name), html.EscapeString(label.Description))
+		}
+
+		fmt.Fprintln(w, "  </tbody>")
+		if _, err := fmt.Fprintln(w, "</table>"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
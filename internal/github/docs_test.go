@@ -0,0 +1,111 @@
+package github
+
+// cSpell:ignore notacolor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestLabels_writeDocs(t *testing.T) {
+	labels := Labels{
+		{Name: "area: cli", Color: "0075ca", Description: "The command line"},
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "area: docs", Color: "a2eeef", Description: "<README> | CONTRIBUTING"},
+	}
+
+	tests := []struct {
+		name   string
+		format OutputFormat
+		labels Labels
+		opts   FormatOptions
+		want   string
+		wantE  bool
+	}{
+		{
+			name:   "markdown",
+			format: Markdown,
+			want: heredoc.Doc(`
+				| Color | Name | Description |
+				| --- | --- | --- |
+				| ![#0075ca](https://img.shields.io/badge/-0075ca-0075ca) | area: cli | The command line |
+				| ![#d73a4a](https://img.shields.io/badge/-d73a4a-d73a4a) | bug | Something isn't working |
+				| ![#a2eeef](https://img.shields.io/badge/-a2eeef-a2eeef) | area: docs | <README> \| CONTRIBUTING |
+			`),
+		},
+		{
+			name:   "markdown grouped by scope",
+			format: Markdown,
+			opts: FormatOptions{
+				GroupByScope: true,
+			},
+			want: heredoc.Doc(`
+				### area
+
+				| Color | Name | Description |
+				| --- | --- | --- |
+				| ![#0075ca](https://img.shields.io/badge/-0075ca-0075ca) | area: cli | The command line |
+				| ![#a2eeef](https://img.shields.io/badge/-a2eeef-a2eeef) | area: docs | <README> \| CONTRIBUTING |
+
+				### Other
+
+				| Color | Name | Description |
+				| --- | --- | --- |
+				| ![#d73a4a](https://img.shields.io/badge/-d73a4a-d73a4a) | bug | Something isn't working |
+			`),
+		},
+		{
+			name:   "html grouped by scope",
+			format: HTML,
+			opts: FormatOptions{
+				GroupByScope: true,
+			},
+			want: heredoc.Doc(`
+				<h3>area</h3>
+				<table>
+				  <thead>
+				    <tr><th>Color</th><th>Name</th><th>Description</th></tr>
+				  </thead>
+				  <tbody>
+				    <tr><td><span style="background-color:#0075ca;color:#FFFFFF;border-radius:2em;padding:0 7px;font-size:12px;font-weight:500">#0075ca</span></td><td>area: cli</td><td>The command line</td></tr>
+				    <tr><td><span style="background-color:#a2eeef;color:#000000;border-radius:2em;padding:0 7px;font-size:12px;font-weight:500">#a2eeef</span></td><td>area: docs</td><td>&lt;README&gt; | CONTRIBUTING</td></tr>
+				  </tbody>
+				</table>
+
+				<h3>Other</h3>
+				<table>
+				  <thead>
+				    <tr><th>Color</th><th>Name</th><th>Description</th></tr>
+				  </thead>
+				  <tbody>
+				    <tr><td><span style="background-color:#d73a4a;color:#FFFFFF;border-radius:2em;padding:0 7px;font-size:12px;font-weight:500">#d73a4a</span></td><td>bug</td><td>Something isn&#39;t working</td></tr>
+				  </tbody>
+				</table>
+			`),
+		},
+		{
+			name:   "html invalid color",
+			format: HTML,
+			labels: Labels{{Name: "invalid", Color: "notacolor"}},
+			wantE:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := labels
+			if tt.labels != nil {
+				labels = tt.labels
+			}
+
+			buf := &bytes.Buffer{}
+			if err := labels.WriteWith(tt.format, buf, tt.opts); (err != nil) != tt.wantE {
+				t.Errorf("WriteWith() error = %v, expected error %v", err, tt.wantE)
+			} else if !tt.wantE && buf.String() != tt.want {
+				t.Errorf("WriteWith() = %v, expected %v", buf.String(), tt.want)
+			}
+		})
+	}
+}
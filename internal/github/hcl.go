@@ -0,0 +1,348 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// This file implements a minimal parser for the subset of HCL needed to read labels from Terraform files:
+// blocks, attributes, and string literals. Other expressions are skipped and reported as non-literal values.
+
+type hclTokenKind int
+
+const (
+	hclEOF hclTokenKind = iota
+	hclNewline
+	hclIdent
+	hclString
+	hclPunct
+)
+
+type hclToken struct {
+	kind  hclTokenKind
+	text  string
+	pos   position
+	plain bool // String contains no interpolation or directives.
+}
+
+type hclError struct {
+	pos position
+	msg string
+}
+
+func (e *hclError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.pos.line, e.pos.column, e.msg)
+}
+
+type hclLexer struct {
+	data   []byte
+	offset int
+	line   int
+	column int
+}
+
+func (l *hclLexer) errorf(pos position, format string, a ...interface{}) error {
+	return &hclError{pos, fmt.Sprintf(format, a...)}
+}
+
+func (l *hclLexer) pos() position {
+	return position{l.line, l.column}
+}
+
+func (l *hclLexer) peek(n int) byte {
+	if l.offset+n < len(l.data) {
+		return l.data[l.offset+n]
+	}
+	return 0
+}
+
+func (l *hclLexer) advance() rune {
+	r, size := utf8.DecodeRune(l.data[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column += size
+	}
+	return r
+}
+
+func (l *hclLexer) next() (hclToken, error) {
+	for l.offset < len(l.data) {
+		c := l.data[l.offset]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			l.advance()
+		case c == '#' || c == '/' && l.peek(1) == '/':
+			for l.offset < len(l.data) && l.data[l.offset] != '\n' {
+				l.advance()
+			}
+		case c == '/' && l.peek(1) == '*':
+			start := l.pos()
+			l.advance()
+			l.advance()
+			for !(l.peek(0) == '*' && l.peek(1) == '/') {
+				if l.offset >= len(l.data) {
+					return hclToken{}, l.errorf(start, "unterminated comment")
+				}
+				l.advance()
+			}
+			l.advance()
+			l.advance()
+		default:
+			return l.token()
+		}
+	}
+
+	return hclToken{kind: hclEOF, pos: l.pos()}, nil
+}
+
+func (l *hclLexer) token() (hclToken, error) {
+	start := l.pos()
+	c := l.data[l.offset]
+
+	if c == '\n' {
+		l.advance()
+		return hclToken{kind: hclNewline, text: "\n", pos: start}, nil
+	}
+
+	if c == '"' {
+		return l.string()
+	}
+
+	if c == '<' && l.peek(1) == '<' {
+		return hclToken{}, l.errorf(start, "heredoc strings are not supported")
+	}
+
+	r, _ := utf8.DecodeRune(l.data[l.offset:])
+	if r == '_' || unicode.IsLetter(r) {
+		var sb strings.Builder
+		for l.offset < len(l.data) {
+			r, _ := utf8.DecodeRune(l.data[l.offset:])
+			if r != '_' && r != '-' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			sb.WriteRune(l.advance())
+		}
+		return hclToken{kind: hclIdent, text: sb.String(), pos: start}, nil
+	}
+
+	return hclToken{kind: hclPunct, text: string(l.advance()), pos: start}, nil
+}
+
+func (l *hclLexer) string() (hclToken, error) {
+	start := l.pos()
+	l.advance()
+
+	var sb strings.Builder
+	plain := true
+
+	for {
+		if l.offset >= len(l.data) || l.data[l.offset] == '\n' {
+			return hclToken{}, l.errorf(start, "unterminated string")
+		}
+
+		c := l.data[l.offset]
+		switch {
+		case c == '"':
+			l.advance()
+			return hclToken{kind: hclString, text: sb.String(), pos: start, plain: plain}, nil
+		case c == '\\':
+			escape := l.pos()
+			l.advance()
+			if l.offset >= len(l.data) {
+				return hclToken{}, l.errorf(start, "unterminated string")
+			}
+			switch e := l.advance(); e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\':
+				sb.WriteRune(e)
+			case 'u', 'U':
+				n := 4
+				if e == 'U' {
+					n = 8
+				}
+				if l.offset+n > len(l.data) {
+					return hclToken{}, l.errorf(escape, "invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(string(l.data[l.offset:l.offset+n]), 16, 32)
+				if err != nil {
+					return hclToken{}, l.errorf(escape, "invalid unicode escape")
+				}
+				for i := 0; i < n; i++ {
+					l.advance()
+				}
+				sb.WriteRune(rune(code))
+			default:
+				return hclToken{}, l.errorf(escape, "invalid escape sequence \"\\%c\"", e)
+			}
+		case (c == '$' || c == '%') && l.peek(1) == c && l.peek(2) == '{':
+			// "$${" and "%%{" are escaped template sequences.
+			l.advance()
+			sb.WriteRune(l.advance())
+			sb.WriteRune(l.advance())
+		case (c == '$' || c == '%') && l.peek(1) == '{':
+			plain = false
+			sb.WriteRune(l.advance())
+		default:
+			sb.WriteRune(l.advance())
+		}
+	}
+}
+
+type hclAttribute struct {
+	pos     position
+	value   string
+	literal bool
+}
+
+type hclBlock struct {
+	typ    string
+	labels []string
+	pos    position
+	attrs  map[string]hclAttribute
+	blocks []*hclBlock
+}
+
+type hclParser struct {
+	lexer hclLexer
+	tok   hclToken
+}
+
+// parseHCL parses data into a root block containing top-level attributes and blocks.
+func parseHCL(data []byte) (*hclBlock, error) {
+	p := &hclParser{
+		lexer: hclLexer{data: data, line: 1, column: 1},
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	root := &hclBlock{pos: position{1, 1}}
+	if err := p.body(root, false); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+func (p *hclParser) advance() (err error) {
+	p.tok, err = p.lexer.next()
+	return
+}
+
+func (p *hclParser) body(block *hclBlock, nested bool) error {
+	block.attrs = make(map[string]hclAttribute)
+
+	for {
+		for p.tok.kind == hclNewline {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case p.tok.kind == hclEOF:
+			if nested {
+				return p.lexer.errorf(block.pos, "unclosed block %q", block.typ)
+			}
+			return nil
+		case p.tok.kind == hclPunct && p.tok.text == "}":
+			if !nested {
+				return p.lexer.errorf(p.tok.pos, `unexpected "}"`)
+			}
+			return p.advance()
+		case p.tok.kind != hclIdent:
+			return p.lexer.errorf(p.tok.pos, "expected an attribute or block, got %q", p.tok.text)
+		}
+
+		name := p.tok
+		if err := p.advance(); err != nil {
+			return err
+		}
+
+		if p.tok.kind == hclPunct && p.tok.text == "=" {
+			if err := p.advance(); err != nil {
+				return err
+			}
+
+			attr, err := p.expression()
+			if err != nil {
+				return err
+			}
+
+			block.attrs[name.text] = attr
+			continue
+		}
+
+		child := &hclBlock{typ: name.text, pos: name.pos}
+		for p.tok.kind == hclString || p.tok.kind == hclIdent {
+			child.labels = append(child.labels, p.tok.text)
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+
+		if p.tok.kind != hclPunct || p.tok.text != "{" {
+			return p.lexer.errorf(p.tok.pos, `expected "=" or "{" after %q`, name.text)
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+
+		if err := p.body(child, true); err != nil {
+			return err
+		}
+
+		block.blocks = append(block.blocks, child)
+	}
+}
+
+// expression reads tokens to the end of the attribute and returns its value if it is a single string literal.
+func (p *hclParser) expression() (hclAttribute, error) {
+	attr := hclAttribute{pos: p.tok.pos}
+
+	var tokens []hclToken
+	depth := 0
+
+	for {
+		tok := p.tok
+		if tok.kind == hclEOF || depth == 0 && (tok.kind == hclNewline || tok.kind == hclPunct && tok.text == "}") {
+			break
+		}
+
+		if tok.kind == hclPunct {
+			switch tok.text {
+			case "(", "[", "{":
+				depth++
+			case ")", "]", "}":
+				depth--
+			}
+		}
+
+		tokens = append(tokens, tok)
+		if err := p.advance(); err != nil {
+			return attr, err
+		}
+	}
+
+	if len(tokens) == 0 {
+		return attr, p.lexer.errorf(attr.pos, "expected an expression")
+	}
+
+	if len(tokens) == 1 && tokens[0].kind == hclString && tokens[0].plain {
+		attr.value = tokens[0].text
+		attr.literal = true
+	}
+
+	return attr, nil
+}
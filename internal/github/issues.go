@@ -0,0 +1,320 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Issue is an issue or pull request.
+type Issue struct {
+	Number            int
+	Title             string
+	Body              string
+	URL               string
+	AuthorAssociation string
+	PullRequest       bool
+	Labels            Labels
+
+	// State is "open" or "closed".
+	State     string
+	CreatedAt time.Time
+
+	// ClosedAt is nil if the issue is open.
+	ClosedAt *time.Time
+}
+
+// InRepo returns whether the issue URL is in the repository owner/repo.
+func (i Issue) InRepo(owner, repo string) bool {
+	u, err := url.Parse(i.URL)
+	if err != nil {
+		return false
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	return len(parts) > 2 && strings.EqualFold(parts[0], owner) && strings.EqualFold(parts[1], repo)
+}
+
+// PullRequest contains details of a pull request not included in Issue.
+type PullRequest struct {
+	Branch string
+	Files  []string
+}
+
+// LabelEvent is a label applied to or removed from an issue or pull request.
+type LabelEvent struct {
+	// Event is "labeled" or "unlabeled".
+	Event     string
+	Actor     string
+	CreatedAt time.Time
+	Issue     Issue
+
+	// Label is the label at the time of the event.
+	Label Label
+}
+
+// issue is an issue or pull request returned from the REST API.
+type issue struct {
+	Number            int
+	Title             string
+	Body              string
+	URL               string    `json:"html_url"`
+	AuthorAssociation string    `json:"author_association"`
+	PullRequest       *struct{} `json:"pull_request"`
+	Labels            Labels
+	State             string
+	CreatedAt         time.Time  `json:"created_at"`
+	ClosedAt          *time.Time `json:"closed_at"`
+}
+
+func (i issue) issue() Issue {
+	return Issue{
+		Number:            i.Number,
+		Title:             i.Title,
+		Body:              i.Body,
+		URL:               i.URL,
+		AuthorAssociation: i.AuthorAssociation,
+		PullRequest:       i.PullRequest != nil,
+		Labels:            i.Labels,
+		State:             i.State,
+		CreatedAt:         i.CreatedAt,
+		ClosedAt:          i.ClosedAt,
+	}
+}
+
+// AddIssueLabels adds labels by name to the issue or pull request and returns all its labels.
+func (c *Client) AddIssueLabels(number int, names []string) (Labels, error) {
+	buf, err := c.labels.AddIssueLabels(number, names)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels Labels
+	if err = json.Unmarshal(buf.Bytes(), &labels); err != nil {
+		return nil, fmt.Errorf("failed to read labels; error: %w, data: %s", err, buf.String())
+	}
+
+	return labels, nil
+}
+
+// RemoveIssueLabel removes a label by name from the issue or pull request.
+// It returns false without an error if the label was not applied.
+func (c *Client) RemoveIssueLabel(number int, name string) (bool, error) {
+	if err := c.labels.RemoveIssueLabel(number, name); err != nil {
+		// gh writes the status like "(HTTP 404)" to stderr.
+		if strings.Contains(err.Error(), "HTTP 404") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SearchIssues returns issues and pull requests in the repository matching query like "is:open label:bug".
+// Queries that select other repositories with "repo:", "org:", or "user:" qualifiers return an error.
+func (c *Client) SearchIssues(query string) ([]Issue, error) {
+	if c.search == nil {
+		return nil, errors.New("searching issues is not supported")
+	}
+
+	for _, term := range strings.Fields(query) {
+		qualifier := strings.ToLower(strings.TrimPrefix(term, "-"))
+		for _, prefix := range []string{"repo:", "org:", "user:"} {
+			if strings.HasPrefix(qualifier, prefix) {
+				return nil, fmt.Errorf("search query cannot include %q because only the selected repository is searched", term)
+			}
+		}
+	}
+
+	buf, err := c.search.SearchIssues(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return readIssues(buf)
+}
+
+func readIssues(buf bytes.Buffer) ([]Issue, error) {
+	var issues []Issue
+
+	// Paginated responses are concatenated.
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var resp struct {
+			Items []struct {
+				Number      int
+				Title       string
+				URL         string    `json:"html_url"`
+				PullRequest *struct{} `json:"pull_request"`
+				Labels      Labels
+			}
+		}
+		if err := dec.Decode(&resp); err != nil {
+			return nil, fmt.Errorf("failed to read issues; error: %w", err)
+		}
+
+		for _, item := range resp.Items {
+			issues = append(issues, Issue{
+				Number:      item.Number,
+				Title:       item.Title,
+				URL:         item.URL,
+				PullRequest: item.PullRequest != nil,
+				Labels:      item.Labels,
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// GetIssue gets an issue or pull request.
+func (c *Client) GetIssue(number int) (Issue, error) {
+	if c.issues == nil {
+		return Issue{}, errors.New("getting issues is not supported")
+	}
+
+	buf, err := c.issues.GetIssue(number)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	var i issue
+	if err = json.Unmarshal(buf.Bytes(), &i); err != nil {
+		return Issue{}, fmt.Errorf("failed to read issue; error: %w, data: %s", err, buf.String())
+	}
+
+	return i.issue(), nil
+}
+
+// ListIssues lists all issues and pull requests in state "open", "closed", or "all".
+func (c *Client) ListIssues(state string) ([]Issue, error) {
+	if c.issues == nil {
+		return nil, errors.New("listing issues is not supported")
+	}
+
+	buf, err := c.issues.ListIssues(state)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []Issue
+
+	// Paginated responses are concatenated.
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var page []issue
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to read issues; error: %w", err)
+		}
+
+		for _, i := range page {
+			issues = append(issues, i.issue())
+		}
+	}
+
+	return issues, nil
+}
+
+// GetPullRequest gets the head branch and changed files of a pull request.
+func (c *Client) GetPullRequest(number int) (PullRequest, error) {
+	if c.issues == nil {
+		return PullRequest{}, errors.New("getting pull requests is not supported")
+	}
+
+	buf, err := c.issues.GetPullRequest(number)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	var resp struct {
+		Head struct {
+			Ref string
+		}
+	}
+	if err = json.Unmarshal(buf.Bytes(), &resp); err != nil {
+		return PullRequest{}, fmt.Errorf("failed to read pull request; error: %w, data: %s", err, buf.String())
+	}
+
+	buf, err = c.issues.ListPullRequestFiles(number)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	pr := PullRequest{
+		Branch: resp.Head.Ref,
+	}
+
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var page []struct {
+			Filename string
+		}
+		if err := dec.Decode(&page); err != nil {
+			return PullRequest{}, fmt.Errorf("failed to read pull request files; error: %w", err)
+		}
+
+		for _, file := range page {
+			pr.Files = append(pr.Files, file.Filename)
+		}
+	}
+
+	return pr, nil
+}
+
+// ListLabelEvents lists labels applied to or removed from issues and pull requests in the repository, most recent first.
+func (c *Client) ListLabelEvents() ([]LabelEvent, error) {
+	if c.issues == nil {
+		return nil, errors.New("listing issue events is not supported")
+	}
+
+	buf, err := c.issues.ListIssueEvents()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []LabelEvent
+
+	// Paginated responses are concatenated.
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var page []struct {
+			Event string
+			Actor *struct {
+				Login string
+			}
+			CreatedAt time.Time `json:"created_at"`
+			Issue     issue
+			Label     *Label
+		}
+		if err := dec.Decode(&page); err != nil {
+			return nil, fmt.Errorf("failed to read issue events; error: %w", err)
+		}
+
+		for _, e := range page {
+			if (e.Event != "labeled" && e.Event != "unlabeled") || e.Label == nil {
+				continue
+			}
+
+			event := LabelEvent{
+				Event:     e.Event,
+				CreatedAt: e.CreatedAt,
+				Issue:     e.Issue.issue(),
+				Label:     *e.Label,
+			}
+
+			// Actors of deleted accounts are null.
+			if e.Actor != nil {
+				event.Actor = e.Actor.Login
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
@@ -1,13 +1,13 @@
 package github
 
 import (
+	"encoding"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
-
-	"github.com/heaths/gh-label/internal/utils"
+	"unicode/utf8"
 )
 
 const labelFields = 4
@@ -24,14 +24,138 @@ type Labels []Label
 type OutputFormat string
 
 const (
-	CSV  OutputFormat = "csv"
-	JSON OutputFormat = "json"
+	CSV       OutputFormat = "csv"
+	HTML      OutputFormat = "html"
+	JSON      OutputFormat = "json"
+	Markdown  OutputFormat = "markdown"
+	SVG       OutputFormat = "svg"
+	Terraform OutputFormat = "terraform"
+	TSV       OutputFormat = "tsv"
 )
 
+// formatAliases maps file extensions to formats with different names.
+var formatAliases = map[string]string{
+	"htm": "html",
+	"md":  "markdown",
+	"tf":  "terraform",
+}
+
+// FormatOptions configures how labels are read or written.
+type FormatOptions struct {
+	// Delimiter separates fields in CSV or TSV. The default is "," for CSV and a tab for TSV.
+	Delimiter rune
+
+	// GroupByScope groups labels in HTML or Markdown by the scope preceding ScopeSeparator in their names.
+	GroupByScope bool
+
+	// ScopeSeparator separates a scope from the rest of a label name. The default is ":".
+	ScopeSeparator string
+
+	// Repository is the repository name in Terraform resources. The default references var.repository.
+	Repository string
+
+	// Sort writes labels sorted by name without regard to case, comparing numbers by value.
+	Sort bool
+
+	// ColorCase writes colors in lowercase or uppercase without a "#" prefix. The default writes colors as they are.
+	ColorCase ColorCase
+
+	// OmitURL omits label URLs.
+	OmitURL bool
+
+	// OmitEmptyDescriptions omits the description column from CSV or TSV if no label has a description.
+	// Empty descriptions are always omitted from other formats.
+	OmitEmptyDescriptions bool
+
+	// Fields are the names of fields to write to CSV, JSON, or TSV in order. The default writes all fields.
+	Fields []string
+
+	// Columns are additional columns written to CSV, JSON, or TSV after Fields.
+	Columns []Column
+}
+
+// Column is an additional column computed for each label.
+type Column struct {
+	// Name is the header in CSV or TSV, and the property name in JSON.
+	Name string
+
+	// Value returns the value for a label. A nil value is written as an empty string to CSV or TSV and omitted from JSON.
+	// Other values are marshaled to JSON, and written to CSV or TSV as text if they implement encoding.TextMarshaler,
+	// then as strings if they implement fmt.Stringer, or else formatted with fmt.Sprint.
+	Value func(Label) interface{}
+}
+
+// text formats a column value for CSV or TSV.
+func (c Column) text(label Label) (string, error) {
+	switch value := c.Value(label).(type) {
+	case nil:
+		return "", nil
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		return string(text), err
+	case fmt.Stringer:
+		return value.String(), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+func (opts FormatOptions) delimiter(format OutputFormat) rune {
+	if opts.Delimiter != 0 {
+		return opts.Delimiter
+	}
+
+	if format == TSV {
+		return '\t'
+	}
+
+	return ','
+}
+
+// ParseDelimiter returns the single character s, or a tab if s is "\t" or "tab".
+func ParseDelimiter(s string) (rune, error) {
+	if s == `\t` || strings.EqualFold(s, "tab") {
+		return '\t', nil
+	}
+
+	runes := []rune(s)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' || runes[0] == utf8.RuneError {
+		return 0, fmt.Errorf(`delimiter must be a single character other than a quote or newline, got %q`, s)
+	}
+
+	return runes[0], nil
+}
+
+func isDelimited(format OutputFormat) bool {
+	return format == CSV || format == TSV
+}
+
 func SupportedOutputFormat(format string) (string, error) {
+	return supportedFormat(format, OutputFormats())
+}
+
+func OutputFormats() []string {
+	// These must remain sorted.
+	return []string{"csv", "html", "json", "markdown", "svg", "terraform", "tsv"}
+}
+
+// SupportedInputFormat returns the normalized format, or an error if labels cannot be read from it.
+func SupportedInputFormat(format string) (string, error) {
+	return supportedFormat(format, InputFormats())
+}
+
+// InputFormats returns the sorted formats labels can be read from.
+func InputFormats() []string {
+	// These must remain sorted.
+	return []string{"csv", "json", "terraform", "tsv"}
+}
+
+func supportedFormat(format string, formats []string) (string, error) {
 	format = strings.TrimPrefix(format, ".")
 	format = strings.ToLower(format)
-	formats := OutputFormats()
+	if alias, ok := formatAliases[format]; ok {
+		format = alias
+	}
 
 	for _, str := range formats {
 		if str == format {
@@ -42,11 +166,6 @@ func SupportedOutputFormat(format string) (string, error) {
 	return "", fmt.Errorf("unsupported format %q, expected %v", format, formats)
 }
 
-func OutputFormats() []string {
-	// These must remain sorted.
-	return []string{"csv", "json"}
-}
-
 func (label *Label) strings() []string {
 	return []string{
 		label.Name,
@@ -73,33 +192,218 @@ func (labels *Labels) strings() [][]string {
 	return arr
 }
 
+// project returns only the given fields from each record.
+func project(record []string, fields []int) []string {
+	projected := make([]string, len(fields))
+	for i, field := range fields {
+		projected[i] = record[field]
+	}
+	return projected
+}
+
+// ParseFields parses a comma-separated list of unique, case-insensitive field names like "name,color".
+func ParseFields(s string) ([]string, error) {
+	var labels Labels
+	headers := labels.headers()
+
+	var fields []string
+	for _, field := range strings.Split(s, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+
+		known := false
+		for _, header := range headers {
+			known = known || field == header
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown field %q, expected %v", field, headers)
+		}
+
+		for _, f := range fields {
+			if f == field {
+				return nil, fmt.Errorf("duplicate field %q", field)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// fields returns the indexes of fields in the order of Labels.headers() to write to CSV, JSON, or TSV.
+func (labels *Labels) fields(opts FormatOptions) []int {
+	headers := labels.headers()
+
+	names := opts.Fields
+	if len(names) == 0 {
+		names = headers
+	}
+
+	fields := make([]int, 0, len(names))
+	for _, name := range names {
+		for i, header := range headers {
+			if name != header {
+				continue
+			}
+
+			switch {
+			case i == 2 && opts.OmitEmptyDescriptions && !labels.hasDescriptions():
+			case i == 3 && opts.OmitURL:
+			default:
+				fields = append(fields, i)
+			}
+		}
+	}
+
+	return fields
+}
+
+func (labels *Labels) hasDescriptions() bool {
+	for _, label := range *labels {
+		if label.Description != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes labels sorted in the given format, so identical sets of labels are written identically regardless of their order.
 func (labels *Labels) Write(format OutputFormat, w io.Writer) error {
-	if format == CSV {
+	return labels.WriteWith(format, w, FormatOptions{Sort: true})
+}
+
+// WriteWith writes labels in the given format with options.
+// Output depends only on labels and opts, so identical labels are written identically; set opts.Sort to also ignore their order.
+func (labels *Labels) WriteWith(format OutputFormat, w io.Writer, opts FormatOptions) error {
+	normalized := labels.normalize(opts)
+	labels = &normalized
+
+	if isDelimited(format) {
+		fields := labels.fields(opts)
+
 		csv := csv.NewWriter(w)
-		if err := csv.Write(labels.headers()); err != nil {
+		csv.Comma = opts.delimiter(format)
+		headers := project(labels.headers(), fields)
+		for _, column := range opts.Columns {
+			headers = append(headers, column.Name)
+		}
+		if err := csv.Write(headers); err != nil {
 			return err
 		}
-		return csv.WriteAll(labels.strings())
+		for i, record := range labels.strings() {
+			record = project(record, fields)
+			for _, column := range opts.Columns {
+				value, err := column.text((*labels)[i])
+				if err != nil {
+					return err
+				}
+				record = append(record, value)
+			}
+			if err := csv.Write(record); err != nil {
+				return err
+			}
+		}
+		csv.Flush()
+		return csv.Error()
 	}
 	if format == JSON {
-		json := json.NewEncoder(w)
-		json.SetIndent("", "  ")
-		return json.Encode(*labels)
+		return labels.writeJSON(w, labels.fields(opts), opts.Columns)
+	}
+	if format == Markdown {
+		return labels.writeMarkdown(w, opts)
+	}
+	if format == HTML {
+		return labels.writeHTML(w, opts)
+	}
+	if format == SVG {
+		return labels.writeSVG(w)
+	}
+	if format == Terraform {
+		return labels.writeTerraform(w, opts)
 	}
 	return fmt.Errorf("unknown format %v", format)
 }
 
+// writeJSON writes labels as an indented JSON array of objects with only the given fields in order, followed by any columns.
+// Like the JSON tags on Label, empty descriptions and URLs are omitted.
+func (labels *Labels) writeJSON(w io.Writer, fields []int, columns []Column) error {
+	if len(*labels) == 0 {
+		_, err := fmt.Fprintln(w, "[]")
+		return err
+	}
+
+	headers := labels.headers()
+	var sb strings.Builder
+
+	sb.WriteString("[\n")
+	for i, label := range *labels {
+		values := label.strings()
+
+		var properties []string
+		for _, field := range fields {
+			if field >= 2 && values[field] == "" {
+				continue
+			}
+
+			value, err := json.Marshal(values[field])
+			if err != nil {
+				return err
+			}
+			properties = append(properties, fmt.Sprintf("    %q: %s", headers[field], value))
+		}
+		for _, column := range columns {
+			v := column.Value(label)
+			if v == nil {
+				continue
+			}
+
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			properties = append(properties, fmt.Sprintf("    %q: %s", column.Name, value))
+		}
+
+		if len(properties) == 0 {
+			sb.WriteString("  {}")
+		} else {
+			sb.WriteString("  {\n")
+			sb.WriteString(strings.Join(properties, ",\n"))
+			sb.WriteString("\n  }")
+		}
+
+		if i < len(*labels)-1 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("]\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
 func ReadLabels(format OutputFormat, r io.Reader) (Labels, error) {
+	return ReadLabelsWith(format, r, FormatOptions{})
+}
+
+// ReadLabelsWith reads labels in the given format with options.
+// CSV and TSV columns are mapped by name if the first record is a header containing a "name" column;
+// otherwise, records must contain name, color, description, and url in that order.
+func ReadLabelsWith(format OutputFormat, r io.Reader, opts FormatOptions) (Labels, error) {
 	// Start with capacity for 10 labels. A new repo currently starts with 9.
 	labels := make(Labels, 0, 10)
 
-	if format == CSV {
+	if isDelimited(format) {
 		csv := csv.NewReader(r)
-		csv.FieldsPerRecord = labelFields
+		csv.Comma = opts.delimiter(format)
+		csv.FieldsPerRecord = -1
 		csv.ReuseRecord = true
 		csv.TrimLeadingSpace = true
 
-		for {
+		var columns columns
+		var headerFields int
+		for first := true; ; first = false {
 			record, err := csv.Read()
 			if err == io.EOF {
 				break
@@ -108,7 +412,20 @@ func ReadLabels(format OutputFormat, r io.Reader) (Labels, error) {
 				return nil, err
 			}
 
-			if utils.AreEqualStrings(record, labels.headers()) {
+			if first {
+				if header, ok := headerColumns(record); ok {
+					columns = header
+					headerFields = len(record)
+					continue
+				}
+			}
+
+			if columns != nil {
+				if len(record) != headerFields {
+					line, _ := csv.FieldPos(0)
+					return nil, fmt.Errorf("record on line %d: expected %d fields like the header, got %d", line, headerFields, len(record))
+				}
+				labels = append(labels, columns.label(record))
 				continue
 			}
 
@@ -132,20 +449,85 @@ func ReadLabels(format OutputFormat, r io.Reader) (Labels, error) {
 		return labels, nil
 	}
 
+	if format == Terraform {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := readTerraform(data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if len(entry.nonLiteral) > 0 {
+				i := entry.nonLiteral[0]
+				pos := entry.positions[i]
+				return nil, fmt.Errorf("%d:%d: %s must be a string literal", pos.line, pos.column, entry.fields[i])
+			}
+
+			labels = append(labels, entry.label)
+		}
+
+		return labels, nil
+	}
+
 	return nil, fmt.Errorf("unknown format %v", format)
 }
 
+// readLabel reads a record without a header containing the name and, optionally, the color, description, and url in that order.
 func readLabel(record []string) (*Label, error) {
-	if len(record) != labelFields {
-		return nil, fmt.Errorf("expected %d label fields, got %d", labelFields, len(record))
+	if len(record) == 0 || len(record) > labelFields {
+		return nil, fmt.Errorf("expected 1 to %d label fields, got %d", labelFields, len(record))
+	}
+
+	label := positionalColumns().label(record)
+	return &label, nil
+}
+
+// columns maps each label field in the order of Labels.headers() to a column in a record, or -1 if missing.
+type columns []int
+
+// headerColumns maps case-insensitive field names in a header record to columns.
+// The record is a header if it contains a "name" column, in which case unknown columns are ignored,
+// or if every column is a known field name.
+func headerColumns(record []string) (columns, bool) {
+	var labels Labels
+	headers := labels.headers()
+
+	c := columns{-1, -1, -1, -1}
+	known := 0
+	for i, cell := range record {
+		cell = strings.ToLower(strings.TrimSpace(cell))
+		for j, header := range headers {
+			if cell == header && c[j] < 0 {
+				c[j] = i
+				known++
+			}
+		}
 	}
 
-	label := &Label{
-		record[0],
-		record[1],
-		record[2],
-		record[3],
+	return c, c[0] >= 0 || known == len(record)
+}
+
+// positionalColumns maps label fields to columns in order.
+func positionalColumns() columns {
+	return columns{0, 1, 2, 3}
+}
+
+func (c columns) label(record []string) Label {
+	values := make([]string, len(c))
+	for i, column := range c {
+		if column >= 0 && column < len(record) {
+			values[i] = record[column]
+		}
 	}
 
-	return label, nil
+	return Label{
+		values[0],
+		values[1],
+		values[2],
+		values[3],
+	}
 }
@@ -3,7 +3,9 @@ package github
 import (
 	"bytes"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 )
@@ -38,6 +40,26 @@ func TestSupportedOutputFormats(t *testing.T) {
 			name: ".json",
 			want: "json",
 		},
+		{
+			name: "tsv",
+			want: "tsv",
+		},
+		{
+			name: ".md",
+			want: "markdown",
+		},
+		{
+			name: "markdown",
+			want: "markdown",
+		},
+		{
+			name: ".htm",
+			want: "html",
+		},
+		{
+			name: ".HTML",
+			want: "html",
+		},
 		{
 			name:  "unknown",
 			wantE: true,
@@ -65,13 +87,56 @@ func TestSupportedOutputFormats(t *testing.T) {
 
 func TestOutputFormats(t *testing.T) {
 	got := OutputFormats()
-	want := []string{"csv", "json"}
+	want := []string{"csv", "html", "json", "markdown", "svg", "terraform", "tsv"}
 
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("OutputFormats() = %v, expected %v", got, want)
 	}
 }
 
+func TestSupportedInputFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  string
+		wantE bool
+	}{
+		{
+			name: ".csv",
+			want: "csv",
+		},
+		{
+			name: "JSON",
+			want: "json",
+		},
+		{
+			name: ".tsv",
+			want: "tsv",
+		},
+		{
+			name: ".tf",
+			want: "terraform",
+		},
+		{
+			name:  ".md",
+			wantE: true,
+		},
+		{
+			name:  "html",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := SupportedInputFormat(tt.name); (err != nil) != tt.wantE {
+				t.Errorf("SupportedInputFormat() error = %v, expected error %v", err, tt.wantE)
+			} else if got != tt.want {
+				t.Errorf("SupportedInputFormat() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLabel_strings(t *testing.T) {
 	label := Label{
 		Name:        "test",
@@ -156,24 +221,24 @@ func TestLabels_write(t *testing.T) {
 			name:   "csv",
 			format: CSV,
 			want: heredoc.Doc(`name,color,description,url
-			foo,FF0000,a foo,https://github.com
 			bar,00FF00,a bar,
+			foo,FF0000,a foo,https://github.com
 			`),
 		},
 		{
 			name:   "json",
 			format: JSON,
 			want: heredoc.Doc(`[
+			  {
+			    "name": "bar",
+			    "color": "00FF00",
+			    "description": "a bar"
+			  },
 			  {
 			    "name": "foo",
 			    "color": "FF0000",
 			    "description": "a foo",
 			    "url": "https://github.com"
-			  },
-			  {
-			    "name": "bar",
-			    "color": "00FF00",
-			    "description": "a bar"
 			  }
 			]
 			`),
@@ -197,6 +262,30 @@ func TestLabels_write(t *testing.T) {
 	}
 }
 
+func TestLabels_Write_order(t *testing.T) {
+	labels := Labels{
+		{Name: "p10", Color: "FF0000"},
+		{Name: "Bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "p2", Color: "00FF00"},
+	}
+	reversed := Labels{labels[2], labels[1], labels[0]}
+
+	for _, format := range []OutputFormat{CSV, JSON, Markdown, Terraform} {
+		t.Run(string(format), func(t *testing.T) {
+			var a, b bytes.Buffer
+			if err := labels.Write(format, &a); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := reversed.Write(format, &b); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if a.String() != b.String() {
+				t.Errorf("Write() = %q, expected %q regardless of order", b.String(), a.String())
+			}
+		})
+	}
+}
+
 func TestReadLabel(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -205,10 +294,19 @@ func TestReadLabel(t *testing.T) {
 		wantE bool
 	}{
 		{
-			name:  "too few fields",
-			data:  []string{"1", "2", "3"},
+			name:  "no fields",
+			data:  []string{},
 			wantE: true,
 		},
+		{
+			name: "fewer fields",
+			data: []string{"1", "2", "3"},
+			want: Label{
+				Name:        "1",
+				Color:       "2",
+				Description: "3",
+			},
+		},
 		{
 			name:  "too many fields",
 			data:  []string{"1", "2", "3", "4", "right out"},
@@ -236,3 +334,264 @@ func TestReadLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestLabels_writeWith(t *testing.T) {
+	labels := Labels{
+		Label{
+			"foo",
+			"FF0000",
+			"a foo; not a bar",
+			"",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format OutputFormat
+		opts   FormatOptions
+		want   string
+	}{
+		{
+			name:   "tsv",
+			format: TSV,
+			want:   "name\tcolor\tdescription\turl\nfoo\tFF0000\ta foo; not a bar\t\n",
+		},
+		{
+			name:   "csv with delimiter",
+			format: CSV,
+			opts: FormatOptions{
+				Delimiter: ';',
+			},
+			want: "name;color;description;url\nfoo;FF0000;\"a foo; not a bar\";\n",
+		},
+		{
+			name:   "csv with fields",
+			format: CSV,
+			opts: FormatOptions{
+				Fields: []string{"color", "name"},
+			},
+			want: "color,name\nFF0000,foo\n",
+		},
+		{
+			name:   "json with fields",
+			format: JSON,
+			opts: FormatOptions{
+				Fields: []string{"description", "name", "url"},
+			},
+			want: "[\n  {\n    \"description\": \"a foo; not a bar\",\n    \"name\": \"foo\"\n  }\n]\n",
+		},
+		{
+			name:   "json with empty fields",
+			format: JSON,
+			opts: FormatOptions{
+				Fields: []string{"url"},
+			},
+			want: "[\n  {}\n]\n",
+		},
+		{
+			name:   "csv with columns",
+			format: CSV,
+			opts: FormatOptions{
+				Fields:  []string{"name"},
+				Columns: testColumns,
+			},
+			want: "name,count,since,missing\nfoo,2,2021-06-01T00:00:00Z,\n",
+		},
+		{
+			name:   "json with columns",
+			format: JSON,
+			opts: FormatOptions{
+				Fields:  []string{"name"},
+				Columns: testColumns,
+			},
+			want: "[\n  {\n    \"name\": \"foo\",\n    \"count\": 2,\n    \"since\": \"2021-06-01T00:00:00Z\"\n  }\n]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bytes := &bytes.Buffer{}
+			if err := labels.WriteWith(tt.format, bytes, tt.opts); err != nil {
+				t.Errorf("WriteWith() error = %v", err)
+			} else if bytes.String() != tt.want {
+				t.Errorf("WriteWith() = %q, expected %q", bytes.String(), tt.want)
+			}
+		})
+	}
+}
+
+var testColumns = []Column{
+	{Name: "count", Value: func(Label) interface{} { return 2 }},
+	{Name: "since", Value: func(Label) interface{} { return time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC) }},
+	{Name: "missing", Value: func(Label) interface{} { return nil }},
+}
+
+func TestReadLabelsWith(t *testing.T) {
+	tests := []struct {
+		name   string
+		format OutputFormat
+		opts   FormatOptions
+		data   string
+		want   Labels
+		wantE  bool
+	}{
+		{
+			name:   "headerless",
+			format: CSV,
+			data:   "foo,FF0000,a foo,https://github.com\n",
+			want: Labels{
+				{"foo", "FF0000", "a foo", "https://github.com"},
+			},
+		},
+		{
+			name:   "headerless with fewer fields",
+			format: CSV,
+			data:   "foo,FF0000,a foo\nbar\n",
+			want: Labels{
+				{"foo", "FF0000", "a foo", ""},
+				{"bar", "", "", ""},
+			},
+		},
+		{
+			name:   "headerless with too many fields",
+			format: CSV,
+			data:   "foo,FF0000,a foo,https://github.com,heaths\n",
+			wantE:  true,
+		},
+		{
+			name:   "header without name",
+			format: CSV,
+			data: heredoc.Doc(`Color,Description
+				FF0000,a foo
+			`),
+			want: Labels{
+				{"", "FF0000", "a foo", ""},
+			},
+		},
+		{
+			name:   "header without url",
+			format: CSV,
+			data: heredoc.Doc(`name,color,description
+				foo,FF0000,a foo
+			`),
+			want: Labels{
+				{"foo", "FF0000", "a foo", ""},
+			},
+		},
+		{
+			name:   "reordered header with extra columns",
+			format: CSV,
+			data: heredoc.Doc(`Owner, Description, NAME, Color
+				heaths,a foo,foo,FF0000
+				,a bar,bar,00FF00
+			`),
+			want: Labels{
+				{"foo", "FF0000", "a foo", ""},
+				{"bar", "00FF00", "a bar", ""},
+			},
+		},
+		{
+			name:   "header with inconsistent fields",
+			format: CSV,
+			data: heredoc.Doc(`name,color
+				foo,FF0000,a foo
+			`),
+			wantE: true,
+		},
+		{
+			name:   "semicolon delimiter",
+			format: CSV,
+			opts: FormatOptions{
+				Delimiter: ';',
+			},
+			data: heredoc.Doc(`name;color
+				foo;FF0000
+			`),
+			want: Labels{
+				{"foo", "FF0000", "", ""},
+			},
+		},
+		{
+			name:   "tsv",
+			format: TSV,
+			data:   "color\tname\nFF0000\tfoo\n",
+			want: Labels{
+				{"foo", "FF0000", "", ""},
+			},
+		},
+		{
+			name:   "unknown",
+			format: "unknown",
+			wantE:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadLabelsWith(tt.format, strings.NewReader(tt.data), tt.opts)
+			if (err != nil) != tt.wantE {
+				t.Errorf("ReadLabelsWith() error = %v, expected error %v", err, tt.wantE)
+				return
+			}
+
+			if !tt.wantE && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadLabelsWith() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		arg   string
+		want  []string
+		wantE bool
+	}{
+		{arg: "name", want: []string{"name"}},
+		{arg: "Color, name,description", want: []string{"color", "name", "description"}},
+		{arg: "name,color,description,url", want: []string{"name", "color", "description", "url"}},
+		{arg: "", wantE: true},
+		{arg: "name,", wantE: true},
+		{arg: "name,owner", wantE: true},
+		{arg: "name,Name", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := ParseFields(tt.arg)
+			if (err != nil) != tt.wantE {
+				t.Errorf("ParseFields() error = %v, expected error %v", err, tt.wantE)
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFields() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		arg   string
+		want  rune
+		wantE bool
+	}{
+		{arg: ",", want: ','},
+		{arg: ";", want: ';'},
+		{arg: `\t`, want: '\t'},
+		{arg: "tab", want: '\t'},
+		{arg: "\t", want: '\t'},
+		{arg: "", wantE: true},
+		{arg: ",;", wantE: true},
+		{arg: `"`, wantE: true},
+		{arg: "\n", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			if got, err := ParseDelimiter(tt.arg); (err != nil) != tt.wantE {
+				t.Errorf("ParseDelimiter() error = %v, expected error %v", err, tt.wantE)
+			} else if got != tt.want {
+				t.Errorf("ParseDelimiter() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
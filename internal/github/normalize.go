@@ -0,0 +1,114 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ColorCase is the case of hexadecimal colors when normalizing labels.
+type ColorCase string
+
+const (
+	LowerCase ColorCase = "lower"
+	UpperCase ColorCase = "upper"
+)
+
+// ParseColorCase returns the ColorCase for s, which must be "lower" or "upper".
+func ParseColorCase(s string) (ColorCase, error) {
+	switch c := ColorCase(strings.ToLower(s)); c {
+	case LowerCase, UpperCase:
+		return c, nil
+	}
+
+	return "", fmt.Errorf(`expected %q or %q, got %q`, LowerCase, UpperCase, s)
+}
+
+// normalize returns a copy of labels with options like sorting and color case applied.
+func (labels *Labels) normalize(opts FormatOptions) Labels {
+	normalized := make(Labels, len(*labels))
+	copy(normalized, *labels)
+
+	for i := range normalized {
+		label := &normalized[i]
+		switch opts.ColorCase {
+		case LowerCase:
+			label.Color = strings.ToLower(strings.TrimPrefix(label.Color, "#"))
+		case UpperCase:
+			label.Color = strings.ToUpper(strings.TrimPrefix(label.Color, "#"))
+		}
+
+		if opts.OmitURL {
+			label.URL = ""
+		}
+	}
+
+	if opts.Sort {
+		// Compare exact names and other fields last so identical sets sort identically regardless of input order.
+		sort.SliceStable(normalized, func(i, j int) bool {
+			a, b := normalized[i], normalized[j]
+			if c := naturalCompare(a.Name, b.Name); c != 0 {
+				return c < 0
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			if a.Color != b.Color {
+				return a.Color < b.Color
+			}
+			if a.Description != b.Description {
+				return a.Description < b.Description
+			}
+			return a.URL < b.URL
+		})
+	}
+
+	return normalized
+}
+
+// naturalCompare compares a and b without regard to case, comparing runs of digits by their numeric value
+// so that "p2" sorts before "p10". It returns a negative number if a sorts first, positive if b sorts first, or 0.
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+
+		if isDigit(ra) && isDigit(rb) {
+			da, db := digits(a), digits(b)
+			a, b = a[len(da):], b[len(db):]
+
+			// Compare numeric values by length after trimming leading zeros, then lexically.
+			ta, tb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if len(ta) != len(tb) {
+				return len(ta) - len(tb)
+			}
+			if c := strings.Compare(ta, tb); c != 0 {
+				return c
+			}
+			continue
+		}
+
+		ra, rb = unicode.ToLower(ra), unicode.ToLower(rb)
+		if ra != rb {
+			return int(ra) - int(rb)
+		}
+
+		a, b = a[sa:], b[sb:]
+	}
+
+	return len(a) - len(b)
+}
+
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+func digits(s string) string {
+	i := 0
+	for i < len(s) && isDigit(rune(s[i])) {
+		i++
+	}
+	return s[:i]
+}
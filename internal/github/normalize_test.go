@@ -0,0 +1,139 @@
+package github
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestParseColorCase(t *testing.T) {
+	tests := []struct {
+		s     string
+		want  ColorCase
+		wantE bool
+	}{
+		{s: "lower", want: LowerCase},
+		{s: "UPPER", want: UpperCase},
+		{s: "", wantE: true},
+		{s: "title", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseColorCase(tt.s)
+			if (err != nil) != tt.wantE {
+				t.Errorf("ParseColorCase() error = %v, expected error %v", err, tt.wantE)
+			} else if got != tt.want {
+				t.Errorf("ParseColorCase() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_naturalCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "bug", b: "bug", want: 0},
+		{a: "Bug", b: "bug", want: 0},
+		{a: "bug", b: "docs", want: -1},
+		{a: "Docs", b: "bug", want: 1},
+		{a: "p2", b: "p10", want: -1},
+		{a: "p10", b: "p9", want: 1},
+		{a: "p02", b: "p2", want: 0},
+		{a: "v1.10", b: "v1.9", want: 1},
+		{a: "area", b: "area: cli", want: -1},
+		{a: "é", b: "É", want: 0},
+	}
+
+	sign := func(n int) int {
+		switch {
+		case n < 0:
+			return -1
+		case n > 0:
+			return 1
+		}
+		return 0
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := sign(naturalCompare(tt.a, tt.b)); got != tt.want {
+				t.Errorf("naturalCompare(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabels_WriteWith_normalized(t *testing.T) {
+	labels := Labels{
+		{Name: "p10", Color: "#D73A4A", URL: "https://github.com/heaths/gh-label/labels/p10"},
+		{Name: "Bug", Color: "d73a4a"},
+		{Name: "p2", Color: "A2eeef", URL: "https://github.com/heaths/gh-label/labels/p2"},
+		{Name: "bug", Color: "d73a4a"},
+	}
+	reversed := Labels{labels[3], labels[2], labels[1], labels[0]}
+
+	opts := FormatOptions{
+		Sort:                  true,
+		ColorCase:             LowerCase,
+		OmitURL:               true,
+		OmitEmptyDescriptions: true,
+	}
+
+	want := heredoc.Doc(`
+		name,color
+		Bug,d73a4a
+		bug,d73a4a
+		p2,a2eeef
+		p10,d73a4a
+	`)
+
+	for _, labels := range []Labels{labels, reversed} {
+		buf := &bytes.Buffer{}
+		if err := labels.WriteWith(CSV, buf, opts); err != nil {
+			t.Fatalf("WriteWith() error = %v", err)
+		}
+
+		if got := buf.String(); got != want {
+			t.Errorf("WriteWith() = %v, expected %v", got, want)
+		}
+	}
+
+	// Original labels should not be modified.
+	if labels[0].Color != "#D73A4A" || labels[0].URL == "" {
+		t.Errorf("WriteWith() modified labels: %v", labels[0])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := labels.WriteWith(JSON, buf, FormatOptions{Sort: true, ColorCase: UpperCase, OmitURL: true}); err != nil {
+		t.Fatalf("WriteWith() error = %v", err)
+	}
+
+	want = heredoc.Doc(`
+		[
+		  {
+		    "name": "Bug",
+		    "color": "D73A4A"
+		  },
+		  {
+		    "name": "bug",
+		    "color": "D73A4A"
+		  },
+		  {
+		    "name": "p2",
+		    "color": "A2EEEF"
+		  },
+		  {
+		    "name": "p10",
+		    "color": "D73A4A"
+		  }
+		]
+	`)
+
+	if got := buf.String(); got != want {
+		t.Errorf("WriteWith() = %v, expected %v", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package github
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/heaths/gh-label/internal/utils"
+)
+
+// These approximate how GitHub renders labels, since fonts are not measured.
+const (
+	svgWidth      = 800
+	svgMargin     = 8
+	svgGap        = 8
+	svgPillHeight = 20
+	svgPadding    = 7
+	svgCharWidth  = 7
+	svgFontSize   = 12
+	svgFontFamily = `-apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif`
+)
+
+type svgPill struct {
+	label     Label
+	color     string
+	textColor string
+	x, y      int
+	width     int
+}
+
+// layoutSVG positions labels as pills from left to right, wrapping to a new row when a pill would exceed svgWidth.
+// It returns the pills and the total height of the image.
+func (labels *Labels) layoutSVG() ([]svgPill, int, error) {
+	pills := make([]svgPill, 0, len(*labels))
+	x, y := svgMargin, svgMargin
+
+	for _, label := range *labels {
+		color := strings.TrimPrefix(label.Color, "#")
+		textColor, err := utils.TextColor(color)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid color for label %q; error: %w", label.Name, err)
+		}
+
+		width := utf8.RuneCountInString(label.Name)*svgCharWidth + 2*svgPadding
+		if x > svgMargin && x+width > svgWidth-svgMargin {
+			x = svgMargin
+			y += svgPillHeight + svgGap
+		}
+
+		pills = append(pills, svgPill{
+			label:     label,
+			color:     color,
+			textColor: textColor,
+			x:         x,
+			y:         y,
+			width:     width,
+		})
+
+		x += width + svgGap
+	}
+
+	height := y + svgMargin
+	if len(pills) > 0 {
+		height += svgPillHeight
+	}
+
+	return pills, height, nil
+}
+
+func (labels *Labels) writeSVG(w io.Writer) error {
+	pills, height, err := labels.layoutSVG()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d">`+"\n", svgWidth, height)
+	fmt.Fprintf(w, `  <g font-family="%s" font-size="%d" font-weight="500" text-anchor="middle">`+"\n", html.EscapeString(svgFontFamily), svgFontSize)
+
+	for _, pill := range pills {
+		fmt.Fprintln(w, "    <g>")
+		if pill.label.Description != "" {
+			fmt.Fprintf(w, "      <title>%s</title>\n", html.EscapeString(pill.label.Description))
+		}
+		fmt.Fprintf(w, `      <rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="#%s"/>`+"\n", pill.x, pill.y, pill.width, svgPillHeight, svgPillHeight/2, html.EscapeString(pill.color))
+		fmt.Fprintf(w, `      <text x="%d" y="%d" fill="#%s">%s</text>`+"\n", pill.x+pill.width/2, pill.y+svgPillHeight/2+svgFontSize*3/8, pill.textColor, html.EscapeString(pill.label.Name))
+		fmt.Fprintln(w, "    </g>")
+	}
+
+	fmt.Fprintln(w, "  </g>")
+	_, err = fmt.Fprintln(w, "</svg>")
+	return err
+}
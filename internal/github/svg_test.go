@@ -0,0 +1,120 @@
+package github
+
+// cSpell:ignore notacolor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestLabels_writeSVG(t *testing.T) {
+	labels := Labels{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "<docs>", Color: "#a2eeef"},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := labels.WriteWith(SVG, buf, FormatOptions{}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := heredoc.Doc(`
+		<svg xmlns="http://www.w3.org/2000/svg" width="800" height="36" viewBox="0 0 800 36">
+		  <g font-family="-apple-system, BlinkMacSystemFont, &#34;Segoe UI&#34;, Helvetica, Arial, sans-serif" font-size="12" font-weight="500" text-anchor="middle">
+		    <g>
+		      <title>Something isn&#39;t working</title>
+		      <rect x="8" y="8" width="35" height="20" rx="10" fill="#d73a4a"/>
+		      <text x="25" y="22" fill="#FFFFFF">bug</text>
+		    </g>
+		    <g>
+		      <rect x="51" y="8" width="56" height="20" rx="10" fill="#a2eeef"/>
+		      <text x="79" y="22" fill="#000000">&lt;docs&gt;</text>
+		    </g>
+		  </g>
+		</svg>
+	`)
+
+	if got := buf.String(); got != want {
+		t.Errorf("Write() = %v, expected %v", got, want)
+	}
+}
+
+func TestLabels_layoutSVG(t *testing.T) {
+	tests := []struct {
+		name       string
+		labels     Labels
+		wantRows   int
+		wantHeight int
+		wantE      bool
+	}{
+		{
+			name:       "empty",
+			wantHeight: 16,
+		},
+		{
+			name: "single row",
+			labels: Labels{
+				{Name: "bug", Color: "d73a4a"},
+				{Name: "enhancement", Color: "a2eeef"},
+			},
+			wantRows:   1,
+			wantHeight: 36,
+		},
+		{
+			name: "wrapped",
+			labels: Labels{
+				// Each label is 50*7+14=364 wide, so only two fit in each row.
+				{Name: strings.Repeat("a", 50), Color: "d73a4a"},
+				{Name: strings.Repeat("b", 50), Color: "d73a4a"},
+				{Name: strings.Repeat("c", 50), Color: "d73a4a"},
+			},
+			wantRows:   2,
+			wantHeight: 64,
+		},
+		{
+			name: "too wide",
+			labels: Labels{
+				{Name: strings.Repeat("a", 200), Color: "d73a4a"},
+				{Name: "bug", Color: "d73a4a"},
+			},
+			wantRows:   2,
+			wantHeight: 64,
+		},
+		{
+			name: "invalid color",
+			labels: Labels{
+				{Name: "bug", Color: "notacolor"},
+			},
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pills, height, err := tt.labels.layoutSVG()
+			if (err != nil) != tt.wantE {
+				t.Errorf("layoutSVG() error = %v, expected error %v", err, tt.wantE)
+				return
+			}
+
+			rows := make(map[int]bool)
+			for _, pill := range pills {
+				rows[pill.y] = true
+				if pill.x < svgMargin {
+					t.Errorf("layoutSVG() pill %q x = %d, expected at least %d", pill.label.Name, pill.x, svgMargin)
+				}
+			}
+
+			if len(rows) != tt.wantRows {
+				t.Errorf("layoutSVG() rows = %d, expected %d", len(rows), tt.wantRows)
+			}
+
+			if height != tt.wantHeight {
+				t.Errorf("layoutSVG() height = %d, expected %d", height, tt.wantHeight)
+			}
+		})
+	}
+}
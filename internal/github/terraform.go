@@ -0,0 +1,194 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+const (
+	terraformLabel  = "github_issue_label"
+	terraformLabels = "github_issue_labels"
+
+	// terraformRepository references a variable when FormatOptions.Repository is not set.
+	terraformRepository = "var.repository"
+)
+
+// terraformNames returns stable Terraform resource names for each label.
+// Names are lowercase with runs of characters other than letters, digits, "_", or "-" replaced by "_".
+// Names that would collide are suffixed with "_2", "_3", and so on in order.
+func (labels *Labels) terraformNames() []string {
+	names := make([]string, len(*labels))
+	used := make(map[string]bool)
+
+	for i, label := range *labels {
+		var sb strings.Builder
+		underscore := false
+		for _, r := range strings.ToLower(label.Name) {
+			if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_') {
+				sb.WriteRune(r)
+				underscore = r == '_'
+			} else if !underscore && sb.Len() > 0 {
+				sb.WriteRune('_')
+				underscore = true
+			}
+		}
+
+		name := strings.Trim(sb.String(), "_-")
+		if name == "" {
+			name = "label"
+		} else if !unicode.IsLetter(rune(name[0])) {
+			name = "label_" + name
+		}
+
+		unique := name
+		for n := 2; used[unique]; n++ {
+			unique = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		used[unique] = true
+		names[i] = unique
+	}
+
+	return names
+}
+
+// terraformString quotes s as an HCL string literal, escaping template sequences.
+// HCL supports only the escapes \\, \", \n, \r, \t, \uNNNN, and \UNNNNNNNN, so other control characters use \uNNNN.
+func terraformString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+
+	s = sb.String()
+	s = strings.ReplaceAll(s, "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+	return s
+}
+
+func (labels *Labels) writeTerraform(w io.Writer, opts FormatOptions) error {
+	repository := terraformRepository
+	if opts.Repository != "" {
+		repository = terraformString(opts.Repository)
+	}
+
+	names := labels.terraformNames()
+	for i, label := range *labels {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		// Align values like "terraform fmt".
+		width := len("repository")
+		if label.Description != "" {
+			width = len("description")
+		}
+
+		fmt.Fprintf(w, "resource %q %q {\n", terraformLabel, names[i])
+		fmt.Fprintf(w, "  %-*s = %s\n", width, "repository", repository)
+		fmt.Fprintf(w, "  %-*s = %s\n", width, "name", terraformString(label.Name))
+		fmt.Fprintf(w, "  %-*s = %s\n", width, "color", terraformString(strings.TrimPrefix(label.Color, "#")))
+		if label.Description != "" {
+			fmt.Fprintf(w, "  %-*s = %s\n", width, "description", terraformString(label.Description))
+		}
+		if _, err := fmt.Fprintln(w, "}"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// terraformEntry is a label read from a Terraform resource along with the positions and paths of its attributes,
+// in the order of Labels.headers().
+type terraformEntry struct {
+	label     Label
+	fields    []string
+	positions []position
+
+	// nonLiteral contains the indexes of attributes that are not string literals.
+	nonLiteral []int
+}
+
+// readTerraform reads labels from github_issue_label resources and label blocks in github_issue_labels resources.
+// Other blocks and attributes are ignored.
+func readTerraform(data []byte) ([]terraformEntry, error) {
+	root, err := parseHCL(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []terraformEntry
+	for _, block := range root.blocks {
+		if block.typ != "resource" || len(block.labels) != 2 {
+			continue
+		}
+
+		switch block.labels[0] {
+		case terraformLabel:
+			entries = append(entries, newTerraformEntry(block, fmt.Sprintf("%s.%s.", terraformLabel, block.labels[1])))
+		case terraformLabels:
+			i := 0
+			for _, child := range block.blocks {
+				if child.typ != "label" {
+					continue
+				}
+				entries = append(entries, newTerraformEntry(child, fmt.Sprintf("%s.%s.label[%d].", terraformLabels, block.labels[1], i)))
+				i++
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func newTerraformEntry(block *hclBlock, path string) terraformEntry {
+	var labels Labels
+	headers := labels.headers()
+
+	entry := terraformEntry{
+		fields:    make([]string, len(headers)),
+		positions: make([]position, len(headers)),
+	}
+	values := []*string{&entry.label.Name, &entry.label.Color, &entry.label.Description, &entry.label.URL}
+
+	for i, header := range headers {
+		entry.fields[i] = path + header
+		entry.positions[i] = block.pos
+
+		attr, ok := block.attrs[header]
+		if !ok {
+			continue
+		}
+
+		entry.positions[i] = attr.pos
+		if attr.literal {
+			*values[i] = attr.value
+		} else {
+			entry.nonLiteral = append(entry.nonLiteral, i)
+		}
+	}
+
+	return entry
+}
@@ -0,0 +1,252 @@
+package github
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestLabels_terraformNames(t *testing.T) {
+	labels := Labels{
+		{Name: "bug"},
+		{Name: "good first issue"},
+		{Name: "area: docs"},
+		{Name: "Area/Docs"},
+		{Name: "🐛 crash"},
+		{Name: "1.0"},
+		{Name: "✨"},
+		{Name: "won't-fix"},
+	}
+
+	got := labels.terraformNames()
+	want := []string{
+		"bug",
+		"good_first_issue",
+		"area_docs",
+		"area_docs_2",
+		"crash",
+		"label_1_0",
+		"label",
+		"won_t-fix",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("terraformNames() = %q, expected %q", got, want)
+	}
+}
+
+func TestLabels_writeTerraform(t *testing.T) {
+	labels := Labels{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working", URL: "https://github.com/heaths/gh-label/labels/bug"},
+		{Name: `"${quoted}"`, Color: "#a2eeef"},
+	}
+
+	tests := []struct {
+		name string
+		opts FormatOptions
+		want string
+	}{
+		{
+			name: "variable",
+			want: heredoc.Doc(`
+				resource "github_issue_label" "bug" {
+				  repository  = var.repository
+				  name        = "bug"
+				  color       = "d73a4a"
+				  description = "Something isn't working"
+				}
+
+				resource "github_issue_label" "quoted" {
+				  repository = var.repository
+				  name       = "\"$${quoted}\""
+				  color      = "a2eeef"
+				}
+			`),
+		},
+		{
+			name: "repository",
+			opts: FormatOptions{
+				Repository: "gh-label",
+			},
+			want: heredoc.Doc(`
+				resource "github_issue_label" "bug" {
+				  repository  = "gh-label"
+				  name        = "bug"
+				  color       = "d73a4a"
+				  description = "Something isn't working"
+				}
+
+				resource "github_issue_label" "quoted" {
+				  repository = "gh-label"
+				  name       = "\"$${quoted}\""
+				  color      = "a2eeef"
+				}
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := labels.WriteWith(Terraform, buf, tt.opts); err != nil {
+				t.Fatalf("WriteWith() error = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteWith() = %v, expected %v", got, tt.want)
+			}
+
+			// Make sure exported labels can be imported again, without the URL.
+			got, err := ReadLabels(Terraform, buf)
+			if err != nil {
+				t.Fatalf("ReadLabels() error = %v", err)
+			}
+
+			want := Labels{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+				{Name: `"${quoted}"`, Color: "a2eeef"},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ReadLabels() = %v, expected %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLabels_writeTerraform_controlCharacters(t *testing.T) {
+	labels := Labels{
+		{Name: "bell", Color: "d73a4a", Description: "Rings\a, \"backs\"\b up\\\f\v\x00\x7f\r\n\tand ends é"},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := labels.WriteWith(Terraform, buf, FormatOptions{}); err != nil {
+		t.Fatalf("WriteWith() error = %v", err)
+	}
+
+	want := heredoc.Doc(`
+		resource "github_issue_label" "bell" {
+		  repository  = var.repository
+		  name        = "bell"
+		  color       = "d73a4a"
+		  description = "Rings\u0007, \"backs\"\u0008 up\\\u000C\u000B\u0000\u007F\r\n\tand ends é"
+		}
+	`)
+	if got := buf.String(); got != want {
+		t.Errorf("WriteWith() = %v, expected %v", got, want)
+	}
+
+	got, err := ReadLabels(Terraform, buf)
+	if err != nil {
+		t.Fatalf("ReadLabels() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, labels) {
+		t.Errorf("ReadLabels() = %q, expected %q", got, labels)
+	}
+}
+
+func TestReadLabels_terraform(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  Labels
+		wantE string
+	}{
+		{
+			name: "resources",
+			data: heredoc.Doc(`
+				# Labels for all repositories.
+				variable "repository" {
+				  type = string
+				}
+
+				resource "github_repository" "repo" {
+				  name = var.repository
+				  topics = [
+				    "cli",
+				    "github",
+				  ]
+				}
+
+				resource "github_issue_label" "bug" {
+				  repository  = github_repository.repo.name // Not a literal but unused.
+				  name        = "bug"
+				  color       = "d73a4a"
+				  description = "Something isn't working…"
+				}
+
+				/*
+				resource "github_issue_label" "commented" {}
+				*/
+				resource "github_issue_labels" "labels" {
+				  repository = var.repository
+				  label {
+				    name  = "enhancement"
+				    color = "a2eeef"
+				  }
+				  label { name = "wontfix" }
+				}
+			`),
+			want: Labels{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working…"},
+				{Name: "enhancement", Color: "a2eeef"},
+				{Name: "wontfix"},
+			},
+		},
+		{
+			name: "interpolated name",
+			data: heredoc.Doc(`
+				resource "github_issue_label" "bug" {
+				  name  = "${var.prefix}bug"
+				  color = "d73a4a"
+				}
+			`),
+			wantE: "2:11: github_issue_label.bug.name must be a string literal",
+		},
+		{
+			name: "unclosed block",
+			data: heredoc.Doc(`
+				resource "github_issue_label" "bug" {
+				  name = "bug"
+			`),
+			wantE: `1:1: unclosed block "resource"`,
+		},
+		{
+			name:  "unterminated string",
+			data:  `resource "github_issue_label" "bug" { name = "bug }`,
+			wantE: "1:46: unterminated string",
+		},
+		{
+			name: "heredoc",
+			data: heredoc.Doc(`
+				resource "github_issue_label" "bug" {
+				  description = <<EOT
+				  Something isn't working
+				  EOT
+				}
+			`),
+			wantE: "2:17: heredoc strings are not supported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadLabels(Terraform, strings.NewReader(tt.data))
+			if err != nil {
+				if tt.wantE == "" || err.Error() != tt.wantE {
+					t.Errorf("ReadLabels() error = %v, expected error %q", err, tt.wantE)
+				}
+				return
+			} else if tt.wantE != "" {
+				t.Errorf("ReadLabels() expected error %q", tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadLabels() = %v, expected %v", got, tt.want)
+			}
+		})
+	}
+}
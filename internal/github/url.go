@@ -0,0 +1,38 @@
+package github
+
+import (
+	"net/url"
+	"strings"
+)
+
+// WebURL returns the web URL for a REST API URL like "https://api.github.com/repos/OWNER/REPO/labels/NAME"
+// or, for GitHub Enterprise Server, "https://HOST/api/v3/repos/OWNER/REPO/labels/NAME".
+// Other URLs are returned unchanged.
+func WebURL(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil || u.Host == "" {
+		return apiURL
+	}
+
+	var p string
+	if host := strings.TrimPrefix(u.Host, "api."); host != u.Host && strings.HasPrefix(u.Path, "/repos/") {
+		u.Host = host
+		p = strings.TrimPrefix(u.Path, "/repos")
+	} else if strings.HasPrefix(u.Path, "/api/v3/repos/") {
+		p = strings.TrimPrefix(u.Path, "/api/v3/repos")
+	} else {
+		return apiURL
+	}
+
+	u.Path = p
+	u.RawPath = ""
+	if i := strings.Index(apiURL, "/repos/"); i >= 0 {
+		// Preserve any escaping like "%20" in label names.
+		u.RawPath = apiURL[i+len("/repos"):]
+		if j := strings.IndexAny(u.RawPath, "?#"); j >= 0 {
+			u.RawPath = u.RawPath[:j]
+		}
+	}
+
+	return u.String()
+}
@@ -0,0 +1,369 @@
+package github
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/heaths/gh-label/internal/utils"
+)
+
+// MaxDescriptionLength is the maximum length of a label description GitHub allows.
+const MaxDescriptionLength = 100
+
+// Problem describes an invalid value in a label file.
+type Problem struct {
+	// Line and Column start at 1. Columns are counted in bytes.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+
+	// Field is the CSV column name, JSON path, or Terraform attribute path of the invalid value.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+
+	// label is the 1-based index of the label with the problem, or 0 if the problem is not specific to a label.
+	label int
+}
+
+// Fatal returns whether the problem is not specific to a label, like a syntax error or a row with too many fields,
+// in which case labels may be missing.
+func (p Problem) Fatal() bool {
+	return p.label == 0
+}
+
+func (p Problem) String() string {
+	if p.Field != "" {
+		return fmt.Sprintf("%d:%d: %s: %s", p.Line, p.Column, p.Field, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+type position struct {
+	line   int
+	column int
+}
+
+// ValidateLabels reads labels like ReadLabelsWith but continues past invalid rows or elements
+// and returns every problem found along with the labels that could be read.
+func ValidateLabels(format OutputFormat, r io.Reader, opts FormatOptions) (Labels, []Problem, error) {
+	var v validator
+
+	switch format {
+	case CSV, TSV:
+		v.readCSV(r, opts.delimiter(format))
+	case JSON:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		v.readJSON(data)
+	case Terraform:
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		v.readTerraform(data)
+	default:
+		return nil, nil, fmt.Errorf("unknown format %v", format)
+	}
+
+	return v.labels, v.problems, nil
+}
+
+// ValidLabels returns labels without problems specific to them.
+// Labels may be missing if any problem is fatal.
+func ValidLabels(labels Labels, problems []Problem) Labels {
+	invalid := make(map[int]bool)
+	for _, problem := range problems {
+		if !problem.Fatal() {
+			invalid[problem.label-1] = true
+		}
+	}
+
+	valid := make(Labels, 0, len(labels))
+	for i, label := range labels {
+		if !invalid[i] {
+			valid = append(valid, label)
+		}
+	}
+	return valid
+}
+
+type validator struct {
+	labels   Labels
+	problems []Problem
+
+	// label is the 1-based index of the label being read, or 0 if between labels.
+	label int
+
+	// Map lowercase names to the position they were first defined.
+	names map[string]position
+}
+
+func (v *validator) add(pos position, field, format string, a ...interface{}) {
+	v.problems = append(v.problems, Problem{
+		Line:    pos.line,
+		Column:  pos.column,
+		Field:   field,
+		Message: fmt.Sprintf(format, a...),
+		label:   v.label,
+	})
+}
+
+// validate checks label values at the given positions, indexed by field in the order of Label.strings().
+func (v *validator) validate(label Label, fields []string, positions []position) {
+	v.label = len(v.labels) + 1
+	defer func() { v.label = 0 }()
+
+	if strings.TrimSpace(label.Name) == "" {
+		v.add(positions[0], fields[0], "name is required")
+	} else {
+		if v.names == nil {
+			v.names = make(map[string]position)
+		}
+
+		key := strings.ToLower(label.Name)
+		if pos, ok := v.names[key]; ok {
+			v.add(positions[0], fields[0], "duplicate name %q first defined on line %d", label.Name, pos.line)
+		} else {
+			v.names[key] = positions[0]
+		}
+	}
+
+	if label.Color != "" {
+		if _, err := utils.ValidateColor(label.Color); err != nil {
+			v.add(positions[1], fields[1], "invalid color %q: %s", label.Color, err)
+		}
+	}
+
+	if n := utf8.RuneCountInString(label.Description); n > MaxDescriptionLength {
+		v.add(positions[2], fields[2], "description length %d is greater than %d", n, MaxDescriptionLength)
+	}
+
+	v.labels = append(v.labels, label)
+}
+
+func (v *validator) readCSV(r io.Reader, delimiter rune) {
+	var labels Labels
+	fields := labels.headers()
+
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var columns columns
+	var headerFields int
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			v.add(position{parseErr.Line, parseErr.Column}, "", "%s", parseErr.Err)
+			// Parsing cannot continue reliably after a syntax error.
+			return
+		} else if err != nil {
+			v.add(position{}, "", "%s", err)
+			return
+		}
+
+		line, column := reader.FieldPos(0)
+		if first {
+			if header, ok := headerColumns(record); ok {
+				columns = header
+				headerFields = len(record)
+				continue
+			}
+		}
+
+		if columns != nil && len(record) != headerFields {
+			v.add(position{line, column}, "", "expected %d fields like the header, got %d", headerFields, len(record))
+			continue
+		}
+
+		c := columns
+		if c == nil {
+			if len(record) > labelFields {
+				v.add(position{line, column}, "", "expected 1 to %d label fields, got %d", labelFields, len(record))
+				continue
+			}
+			c = positionalColumns()
+		}
+
+		positions := make([]position, len(fields))
+		for i, index := range c {
+			positions[i] = position{line, column}
+			if index >= 0 && index < len(record) {
+				line, column := reader.FieldPos(index)
+				positions[i] = position{line, column}
+			}
+		}
+
+		v.validate(c.label(record), fields, positions)
+	}
+}
+
+func (v *validator) readJSON(data []byte) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	pos := func(offset int64) position {
+		return offsetPosition(data, offset)
+	}
+
+	syntaxError := func(err error) {
+		// Syntax errors are fatal even within a label.
+		v.label = 0
+
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			v.add(pos(syntaxErr.Offset), "", "%s", err)
+		} else if err == io.EOF {
+			v.add(pos(int64(len(data))), "", "unexpected end of JSON input")
+		} else {
+			v.add(pos(dec.InputOffset()), "", "%s", err)
+		}
+	}
+
+	if tok, err := dec.Token(); err != nil {
+		syntaxError(err)
+		return
+	} else if tok != json.Delim('[') {
+		v.add(pos(0), "", "expected an array of labels")
+		return
+	}
+
+	var labels Labels
+	headers := labels.headers()
+
+	for i := 0; dec.More(); i++ {
+		path := fmt.Sprintf("[%d]", i)
+		start := skipSeparators(data, dec.InputOffset())
+
+		if start >= int64(len(data)) || data[start] != '{' {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				syntaxError(err)
+				return
+			}
+
+			v.add(pos(start), path, "expected a label object")
+			continue
+		}
+
+		if _, err := dec.Token(); err != nil {
+			syntaxError(err)
+			return
+		}
+
+		// Problems with values belong to the label being read.
+		v.label = len(v.labels) + 1
+
+		var label Label
+		positions := []position{pos(start), pos(start), pos(start), pos(start)}
+		values := []*string{&label.Name, &label.Color, &label.Description, &label.URL}
+
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				syntaxError(err)
+				return
+			}
+
+			key, _ := tok.(string)
+			offset := dec.InputOffset()
+
+			var value interface{}
+			if err := dec.Decode(&value); err != nil {
+				syntaxError(err)
+				return
+			}
+
+			index := -1
+			for j, header := range headers {
+				if header == key {
+					index = j
+					break
+				}
+			}
+
+			if index < 0 {
+				// Ignore properties like "default" or "id" from API responses.
+				continue
+			}
+
+			positions[index] = pos(offset)
+			if s, ok := value.(string); ok {
+				*values[index] = s
+			} else if value != nil {
+				v.add(pos(offset), path+"."+key, "expected a string")
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			syntaxError(err)
+			return
+		}
+
+		fields := make([]string, len(headers))
+		for j, header := range headers {
+			fields[j] = path + "." + header
+		}
+
+		v.validate(label, fields, positions)
+	}
+
+	if _, err := dec.Token(); err != nil {
+		syntaxError(err)
+	}
+}
+
+func (v *validator) readTerraform(data []byte) {
+	entries, err := readTerraform(data)
+
+	var hclErr *hclError
+	if errors.As(err, &hclErr) {
+		v.add(hclErr.pos, "", "%s", hclErr.msg)
+		return
+	} else if err != nil {
+		v.add(position{}, "", "%s", err)
+		return
+	}
+
+	for _, entry := range entries {
+		v.label = len(v.labels) + 1
+		for _, i := range entry.nonLiteral {
+			v.add(entry.positions[i], entry.fields[i], "expected a string literal")
+		}
+
+		v.validate(entry.label, entry.fields, entry.positions)
+	}
+}
+
+// offsetPosition returns the line and column of the first value at or after offset in data,
+// skipping whitespace and separators.
+func offsetPosition(data []byte, offset int64) position {
+	offset = skipSeparators(data, offset)
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	column := int(offset) - bytes.LastIndexByte(data[:offset], '\n')
+
+	return position{line, column}
+}
+
+func skipSeparators(data []byte, offset int64) int64 {
+	if offset > int64(len(data)) {
+		return int64(len(data))
+	}
+
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n:,", rune(data[offset])) {
+		offset++
+	}
+
+	return offset
+}
@@ -0,0 +1,267 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+// cSpell:ignore notacolor
+func TestValidateLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		format OutputFormat
+		data   string
+		want   []string
+		wantE  bool
+	}{
+		{
+			name:   "valid csv",
+			format: CSV,
+			data: heredoc.Doc(`name,color,description,url
+				bug,d73a4a,Something isn't working,
+				enhancement,tomato,,
+			`),
+		},
+		{
+			name:   "invalid csv",
+			format: CSV,
+			data: heredoc.Doc(`name,color,description,url
+				bug,notacolor,Something isn't working,
+				,a2eeef,,
+				Bug,d73a4a,,
+				wontfix,ffffff
+				question,d876e3,` + strings.Repeat("?", 101) + `,
+			`),
+			want: []string{
+				"2:5: color: invalid color \"notacolor\": colors must include 6 hexadecimal digits for RGB with optional \"#\" prefix, 3 hexadecimal digits with \"#\" prefix, a color name, rgb(), or hsl()",
+				"3:1: name: name is required",
+				"4:1: name: duplicate name \"Bug\" first defined on line 2",
+				"5:1: expected 4 fields like the header, got 2",
+				"6:17: description: description length 101 is greater than 100",
+			},
+		},
+		{
+			name:   "headerless csv",
+			format: CSV,
+			data: heredoc.Doc(`bug,d73a4a,Something isn't working,
+				wontfix,ffffff
+				question,d876e3,,,
+			`),
+			want: []string{
+				"3:1: expected 1 to 4 label fields, got 5",
+			},
+		},
+		{
+			name:   "reordered tsv",
+			format: TSV,
+			data:   "Color\tNAME\tOwner\nnotacolor\tbug\tpm\n",
+			want: []string{
+				"2:1: color: invalid color \"notacolor\": colors must include 6 hexadecimal digits for RGB with optional \"#\" prefix, 3 hexadecimal digits with \"#\" prefix, a color name, rgb(), or hsl()",
+			},
+		},
+		{
+			name:   "csv syntax error",
+			format: CSV,
+			data: heredoc.Doc(`name,color,description,url
+				bug,d73a4a,"Something isn't working,
+			`),
+			want: []string{
+				"2:38: extraneous or missing \" in quoted-field",
+			},
+		},
+		{
+			name:   "valid json",
+			format: JSON,
+			data: heredoc.Doc(`[
+				{"name": "bug", "color": "d73a4a", "description": "Something isn't working", "default": true},
+				{"name": "enhancement", "color": "#a2eeef"}
+			]`),
+		},
+		{
+			name:   "invalid json",
+			format: JSON,
+			data: heredoc.Doc(`[
+				{
+					"name": "bug",
+					"color": "notacolor"
+				},
+				{"name": "", "color": "a2eeef"},
+				{"name": "BUG", "color": 1},
+				"wontfix"
+			]`),
+			want: []string{
+				"4:12: [0].color: invalid color \"notacolor\": colors must include 6 hexadecimal digits for RGB with optional \"#\" prefix, 3 hexadecimal digits with \"#\" prefix, a color name, rgb(), or hsl()",
+				"6:11: [1].name: name is required",
+				"7:27: [2].color: expected a string",
+				"7:11: [2].name: duplicate name \"BUG\" first defined on line 3",
+				"8:2: [3]: expected a label object",
+			},
+		},
+		{
+			name:   "json syntax error",
+			format: JSON,
+			data: heredoc.Doc(`[
+				{"name": "bug",}
+			]`),
+			want: []string{
+				"2:17: invalid character ',' looking for beginning of value",
+			},
+		},
+		{
+			name:   "json not an array",
+			format: JSON,
+			data:   `{"name": "bug"}`,
+			want: []string{
+				"1:1: expected an array of labels",
+			},
+		},
+		{
+			name:   "json truncated",
+			format: JSON,
+			data:   `[{"name": "bug"}`,
+			want: []string{
+				"1:17: unexpected end of JSON input",
+			},
+		},
+		{
+			name:   "invalid terraform",
+			format: Terraform,
+			data: heredoc.Doc(`
+				resource "github_issue_label" "bug" {
+				  name  = "bug"
+				  color = "notacolor"
+				}
+
+				resource "github_issue_labels" "labels" {
+				  label {
+				    name  = "Bug"
+				    color = var.color
+				  }
+				}
+			`),
+			want: []string{
+				"3:11: github_issue_label.bug.color: invalid color \"notacolor\": colors must include 6 hexadecimal digits for RGB with optional \"#\" prefix, 3 hexadecimal digits with \"#\" prefix, a color name, rgb(), or hsl()",
+				"9:13: github_issue_labels.labels.label[0].color: expected a string literal",
+				"8:13: github_issue_labels.labels.label[0].name: duplicate name \"Bug\" first defined on line 2",
+			},
+		},
+		{
+			name:   "terraform syntax error",
+			format: Terraform,
+			data:   `resource "github_issue_label" "bug" {` + "\n",
+			want: []string{
+				"1:1: unclosed block \"resource\"",
+			},
+		},
+		{
+			name:   "unknown format",
+			format: "unknown",
+			wantE:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problems, err := ValidateLabels(tt.format, strings.NewReader(tt.data), FormatOptions{})
+			if (err != nil) != tt.wantE {
+				t.Errorf("ValidateLabels() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			var got []string
+			for _, problem := range problems {
+				got = append(got, problem.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateLabels() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		format OutputFormat
+		data   string
+		want   []string
+		fatal  bool
+	}{
+		{
+			name:   "valid",
+			format: CSV,
+			data:   "name,color\nbug,d73a4a\nwontfix,ffffff\n",
+			want:   []string{"bug", "wontfix"},
+		},
+		{
+			name:   "invalid labels",
+			format: CSV,
+			data:   "name,color\nbug,notacolor\n,ffffff\nwontfix,ffffff\nWONTFIX,000000\nenhancement,a2eeef\n",
+			want:   []string{"wontfix", "enhancement"},
+		},
+		{
+			name:   "csv syntax error",
+			format: CSV,
+			data:   "name,color\nbug,d73a4a\nwontfix,\"ff\"ff\nenhancement,a2eeef\n",
+			want:   []string{"bug"},
+			fatal:  true,
+		},
+		{
+			name:   "csv field count",
+			format: CSV,
+			data:   "name,color\nbug,d73a4a\nwontfix,ffffff,extra\nenhancement,a2eeef\n",
+			want:   []string{"bug", "enhancement"},
+			fatal:  true,
+		},
+		{
+			name:   "json value",
+			format: JSON,
+			data:   `[{"name": "bug", "color": 1}, {"name": "wontfix"}]`,
+			want:   []string{"wontfix"},
+		},
+		{
+			name:   "json element",
+			format: JSON,
+			data:   `[{"name": "bug"}, "wontfix"]`,
+			want:   []string{"bug"},
+			fatal:  true,
+		},
+		{
+			name:   "json syntax error",
+			format: JSON,
+			data:   `[{"name": "bug"}, {"name": "wontfix", "color": }]`,
+			want:   []string{"bug"},
+			fatal:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, problems, err := ValidateLabels(tt.format, strings.NewReader(tt.data), FormatOptions{})
+			if err != nil {
+				t.Fatalf("ValidateLabels() error = %v", err)
+			}
+
+			var got []string
+			for _, label := range ValidLabels(labels, problems) {
+				got = append(got, label.Name)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidLabels() = %q, want %q", got, tt.want)
+			}
+
+			fatal := false
+			for _, problem := range problems {
+				fatal = fatal || problem.Fatal()
+			}
+			if fatal != tt.fatal {
+				t.Errorf("Fatal() = %v, want %v", fatal, tt.fatal)
+			}
+		})
+	}
+}
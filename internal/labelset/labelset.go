@@ -0,0 +1,330 @@
+package labelset
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format of label set files.
+const Format = "yaml"
+
+// SupportedFormat returns whether format, or a file extension, is a label set.
+func SupportedFormat(format string) bool {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+	return format == "yaml" || format == "yml"
+}
+
+// Origin is the file and line that defined or changed a label. Line is 0 for files other than label sets.
+type Origin struct {
+	Path string `json:"path"`
+	Line int    `json:"line,omitempty"`
+}
+
+func (o Origin) String() string {
+	if o.Line > 0 {
+		return fmt.Sprintf("%s:%d", o.Path, o.Line)
+	}
+	return o.Path
+}
+
+// Label is a resolved label and where it came from.
+type Label struct {
+	github.Label
+
+	// Origins lists where the label was defined followed by where it was overridden, in order.
+	Origins []Origin `json:"origins"`
+}
+
+// Set is a resolved label set in order of definition.
+type Set []Label
+
+// Labels returns the resolved labels.
+func (set Set) Labels() github.Labels {
+	labels := make(github.Labels, len(set))
+	for i, label := range set {
+		labels[i] = label.Label
+	}
+	return labels
+}
+
+// stringList is a YAML scalar or sequence of strings.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*l = stringList{node.Value}
+		return nil
+	}
+
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+
+	*l = list
+	return nil
+}
+
+type file struct {
+	// Extends lists label files whose labels are inherited first.
+	Extends stringList `yaml:"extends"`
+
+	// Include lists label files whose labels are merged after those inherited.
+	Include stringList `yaml:"include"`
+
+	// Labels are added, or override labels with the same name without regard to case.
+	Labels []yaml.Node `yaml:"labels"`
+
+	// Remove lists names of inherited or included labels to remove.
+	Remove []yaml.Node `yaml:"remove"`
+}
+
+type labelDef struct {
+	// If is false to skip the label, typically set by a template like "{{ has .Topics "cli" }}".
+	If *bool `yaml:"if"`
+
+	Name        string  `yaml:"name"`
+	Color       *string `yaml:"color"`
+	Description *string `yaml:"description"`
+}
+
+// Resolver resolves label sets and the label files they extend or include from a file system.
+type Resolver struct {
+	FS fs.FS
+
+	// Options used to read included label files other than label sets, like CSV.
+	FormatOptions github.FormatOptions
+
+	// Data returns the data for templates in label files. It is called at most once and only if a file contains "{{".
+	// If nil, files are not evaluated as templates.
+	Data func() (*TemplateData, error)
+
+	data *TemplateData
+
+	// stack contains paths being resolved to detect cycles.
+	stack []string
+}
+
+// Resolve reads the label set at name in the file system and resolves it into a flat Set.
+func (r *Resolver) Resolve(name string) (Set, error) {
+	return r.resolve(name, nil)
+}
+
+// ResolveData resolves a label set already read from name, like from stdin, regardless of the extension of name.
+// Files it extends or includes are read from the file system relative to name.
+func (r *Resolver) ResolveData(name string, data []byte) (Set, error) {
+	if data == nil {
+		data = []byte{}
+	}
+	return r.resolve(name, data)
+}
+
+// resolve reads the label file at name, or resolves data as a label set if not nil.
+func (r *Resolver) resolve(name string, data []byte) (Set, error) {
+	for i, p := range r.stack {
+		if p == name {
+			cycle := append(append([]string{}, r.stack[i:]...), name)
+			return nil, fmt.Errorf("label files include each other: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	r.stack = append(r.stack, name)
+	defer func() {
+		r.stack = r.stack[:len(r.stack)-1]
+	}()
+
+	// Files are read by extension, but data passed to ResolveData is always a label set.
+	ext := path.Ext(name)
+	labelSet := data != nil || SupportedFormat(ext)
+
+	if data == nil {
+		var err error
+		if data, err = fs.ReadFile(r.FS, name); err != nil {
+			return nil, fmt.Errorf("failed to read %q; error: %w", name, err)
+		}
+	}
+
+	if r.Data != nil && bytes.Contains(data, []byte("{{")) {
+		if r.data == nil {
+			var err error
+			if r.data, err = r.Data(); err != nil {
+				return nil, fmt.Errorf("failed to get template data; error: %w", err)
+			}
+		}
+
+		var err error
+		if data, err = r.data.execute(name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if !labelSet {
+		return r.read(name, ext, data)
+	}
+
+	var f file
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read %q; error: %w", name, err)
+	}
+
+	var set Set
+	for _, refs := range []stringList{f.Extends, f.Include} {
+		for _, ref := range refs {
+			refName, err := join(name, ref)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved, err := r.resolve(refName, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, label := range resolved {
+				set = set.merge(label)
+			}
+		}
+	}
+
+	for _, node := range f.Labels {
+		var def labelDef
+		if err := node.Decode(&def); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, node.Line, err)
+		}
+
+		if strings.TrimSpace(def.Name) == "" {
+			return nil, fmt.Errorf("%s:%d: name is required", name, node.Line)
+		}
+
+		if def.If != nil && !*def.If {
+			continue
+		}
+
+		label := Label{
+			Label:   github.Label{Name: def.Name},
+			Origins: []Origin{{name, node.Line}},
+		}
+		set = set.override(label, def)
+	}
+
+	for _, node := range f.Remove {
+		i := set.index(node.Value)
+		if i < 0 {
+			return nil, fmt.Errorf("%s:%d: cannot remove label %q that is not defined", name, node.Line, node.Value)
+		}
+		set = append(set[:i], set[i+1:]...)
+	}
+
+	return set, nil
+}
+
+// read reads a label file in another format like CSV or JSON.
+func (r *Resolver) read(name, ext string, data []byte) (Set, error) {
+	format, err := github.SupportedInputFormat(ext)
+	if err != nil {
+		return nil, fmt.Errorf("%q has unsupported format %q, expected %v or %s", name, ext, github.InputFormats(), Format)
+	}
+
+	labels, err := github.ReadLabelsWith(github.OutputFormat(format), bytes.NewReader(data), r.FormatOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q; error: %w", name, err)
+	}
+
+	set := make(Set, 0, len(labels))
+	for _, label := range labels {
+		set = set.merge(Label{
+			Label:   label,
+			Origins: []Origin{{Path: name}},
+		})
+	}
+
+	return set, nil
+}
+
+// join resolves ref relative to the directory containing name.
+func join(name, ref string) (string, error) {
+	joined := path.Join(path.Dir(name), ref)
+	if path.IsAbs(ref) || !fs.ValidPath(joined) {
+		return "", fmt.Errorf("%q referenced from %q is outside the root directory", ref, name)
+	}
+	return joined, nil
+}
+
+func (set Set) index(name string) int {
+	for i, label := range set {
+		if strings.EqualFold(label.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// merge adds label, or replaces the fields of the label with the same name that are not empty.
+func (set Set) merge(label Label) Set {
+	def := labelDef{Name: label.Name}
+	if label.Color != "" {
+		def.Color = &label.Color
+	}
+	if label.Description != "" {
+		def.Description = &label.Description
+	}
+
+	return set.override(label, def)
+}
+
+// override adds label, or replaces the name and fields specified in def of the label with the same name.
+func (set Set) override(label Label, def labelDef) Set {
+	i := set.index(def.Name)
+	if i < 0 {
+		if def.Color != nil {
+			label.Color = *def.Color
+		}
+		if def.Description != nil {
+			label.Description = *def.Description
+		}
+		return append(set, label)
+	}
+
+	existing := &set[i]
+	existing.Name = def.Name
+	if def.Color != nil {
+		existing.Color = *def.Color
+	}
+	if def.Description != nil {
+		existing.Description = *def.Description
+	}
+	existing.Origins = append(existing.Origins, label.Origins...)
+
+	return set
+}
+
+// Validate returns problems with resolved labels, like invalid colors, prefixed with where each label was last changed.
+func (set Set) Validate() []string {
+	var problems []string
+	for _, label := range set {
+		origin := label.Origins[len(label.Origins)-1]
+
+		if label.Color != "" {
+			if _, err := utils.ValidateColor(label.Color); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: color: invalid color %q for label %q: %s", origin, label.Color, label.Name, err))
+			}
+		}
+
+		if n := utf8.RuneCountInString(label.Description); n > github.MaxDescriptionLength {
+			problems = append(problems, fmt.Sprintf("%s: description: description length %d for label %q is greater than %d", origin, n, label.Name, github.MaxDescriptionLength))
+		}
+	}
+
+	return problems
+}
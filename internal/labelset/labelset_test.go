@@ -0,0 +1,203 @@
+package labelset
+
+// cSpell:ignore fstest
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func TestSupportedFormat(t *testing.T) {
+	for format, want := range map[string]bool{
+		"yaml":  true,
+		".yml":  true,
+		".YAML": true,
+		"json":  false,
+		"":      false,
+	} {
+		if got := SupportedFormat(format); got != want {
+			t.Errorf("SupportedFormat(%q) = %v, expected %v", format, got, want)
+		}
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	fs := fstest.MapFS{
+		"templates/base.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				labels:
+				  - name: bug
+				    color: d73a4a
+				    description: Something isn't working
+				  - name: wontfix
+				    color: ffffff
+			`)),
+		},
+		"templates/extras.csv": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				name,color,description
+				area: service,0075ca,The service
+				Bug,ee0701,
+			`)),
+		},
+		"service.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				extends: templates/base.yml
+				include:
+				  - templates/extras.csv
+				labels:
+				  - name: bug
+				    description: ""
+				  - name: question
+				    color: d876e3
+				remove: [WONTFIX]
+			`)),
+		},
+		"cycle/a.yml": &fstest.MapFile{
+			Data: []byte("extends: b.yml\n"),
+		},
+		"cycle/b.yml": &fstest.MapFile{
+			Data: []byte("include: [c.yml]\n"),
+		},
+		"cycle/c.yml": &fstest.MapFile{
+			Data: []byte("extends: ./a.yml\n"),
+		},
+		"errors/outside.yml": &fstest.MapFile{
+			Data: []byte("extends: ../../base.yml\n"),
+		},
+		"errors/unknown.yml": &fstest.MapFile{
+			Data: []byte("extend: base.yml\n"),
+		},
+		"errors/remove.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				labels:
+				  - name: bug
+				remove:
+				  - bugs
+			`)),
+		},
+		"errors/name.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				labels:
+				  - color: d73a4a
+			`)),
+		},
+		"errors/format.yml": &fstest.MapFile{
+			Data: []byte("include: labels.md\n"),
+		},
+		"errors/labels.md": &fstest.MapFile{},
+	}
+
+	tests := []struct {
+		name  string
+		want  Set
+		wantE string
+	}{
+		{
+			name: "service.yml",
+			want: Set{
+				{
+					Label: github.Label{Name: "bug", Color: "ee0701"},
+					Origins: []Origin{
+						{"templates/base.yml", 2},
+						{"templates/extras.csv", 0},
+						{"service.yml", 5},
+					},
+				},
+				{
+					Label: github.Label{Name: "area: service", Color: "0075ca", Description: "The service"},
+					Origins: []Origin{
+						{"templates/extras.csv", 0},
+					},
+				},
+				{
+					Label: github.Label{Name: "question", Color: "d876e3"},
+					Origins: []Origin{
+						{"service.yml", 7},
+					},
+				},
+			},
+		},
+		{
+			name:  "cycle/a.yml",
+			wantE: "label files include each other: cycle/a.yml -> cycle/b.yml -> cycle/c.yml -> cycle/a.yml",
+		},
+		{
+			name:  "errors/outside.yml",
+			wantE: `"../../base.yml" referenced from "errors/outside.yml" is outside the root directory`,
+		},
+		{
+			name:  "errors/unknown.yml",
+			wantE: "failed to read \"errors/unknown.yml\"; error: yaml: unmarshal errors:\n  line 1: field extend not found in type labelset.file",
+		},
+		{
+			name:  "errors/remove.yml",
+			wantE: `errors/remove.yml:4: cannot remove label "bugs" that is not defined`,
+		},
+		{
+			name:  "errors/name.yml",
+			wantE: "errors/name.yml:2: name is required",
+		},
+		{
+			name:  "errors/format.yml",
+			wantE: `"errors/labels.md" has unsupported format ".md", expected [csv json terraform tsv] or yaml`,
+		},
+		{
+			name:  "missing.yml",
+			wantE: `failed to read "missing.yml"; error: open missing.yml: file does not exist`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Resolver{FS: fs}
+			got, err := r.Resolve(tt.name)
+			if err != nil {
+				if err.Error() != tt.wantE {
+					t.Errorf("Resolve() error = %q, expected %q", err, tt.wantE)
+				}
+				return
+			} else if tt.wantE != "" {
+				t.Errorf("Resolve() expected error %q", tt.wantE)
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() = %v, expected %v", got, tt.want)
+			}
+
+			if labels := got.Labels(); len(labels) != len(got) || labels[0] != got[0].Label {
+				t.Errorf("Labels() = %v", labels)
+			}
+		})
+	}
+}
+
+func TestResolver_ResolveData(t *testing.T) {
+	fs := fstest.MapFS{
+		"base.json": &fstest.MapFile{
+			Data: []byte(`[{"name": "bug", "color": "d73a4a"}]`),
+		},
+	}
+
+	r := &Resolver{FS: fs}
+	got, err := r.ResolveData("labels.yml", []byte("extends: base.json\n"))
+	if err != nil {
+		t.Fatalf("ResolveData() error = %v", err)
+	}
+
+	want := Set{
+		{
+			Label:   github.Label{Name: "bug", Color: "d73a4a"},
+			Origins: []Origin{{"base.json", 0}},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveData() = %v, expected %v", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package labelset
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// TemplateData is the data available to templates in label files, like "{{ .Repo }}".
+type TemplateData struct {
+	// Owner is the login of the repository owner.
+	Owner string
+
+	// Repo is the name of the repository.
+	Repo string
+
+	// Language is the primary language of the repository, like "Go", or empty if not detected.
+	Language string
+
+	// Visibility is "public", "private", or "internal".
+	Visibility string
+
+	// Topics are the topics of the repository.
+	Topics []string
+
+	// Env contains environment variables from Environ. Referencing a variable that is not set is an error; use the env function instead.
+	// If nil, environment variables are not available, as for label files that are not local.
+	Env map[string]string
+}
+
+// EnvPrefix is the prefix of environment variables available to templates.
+const EnvPrefix = "GH_LABEL_"
+
+// Environ returns environment variables prefixed with EnvPrefix as a map for TemplateData.Env, with the prefix removed.
+// Other variables, like GH_TOKEN, are never available to templates.
+func Environ() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, EnvPrefix) {
+			continue
+		}
+
+		kv = strings.TrimPrefix(kv, EnvPrefix)
+		if i := strings.Index(kv, "="); i > 0 {
+			env[kv[:i]] = kv[i+1:]
+		}
+	}
+	return env
+}
+
+// funcs returns functions available to templates in addition to those built into text/template.
+// These are documented in README.md.
+func (data *TemplateData) funcs() template.FuncMap {
+	return template.FuncMap{
+		"has": func(list []string, value string) bool {
+			for _, item := range list {
+				if strings.EqualFold(item, value) {
+					return true
+				}
+			}
+			return false
+		},
+		"env": func(name string, def ...string) (string, error) {
+			if data.Env == nil {
+				return "", fmt.Errorf("environment variables are only available to local label files")
+			}
+			if value, ok := data.Env[name]; ok {
+				return value, nil
+			}
+			if len(def) > 1 {
+				return "", fmt.Errorf("env expects at most one default, got %d", len(def))
+			}
+			return strings.Join(def, ""), nil
+		},
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+	}
+}
+
+// execute evaluates data from the file name as a template.
+func (data *TemplateData) execute(name string, text []byte) ([]byte, error) {
+	t, err := template.New(name).Option("missingkey=error").Funcs(data.funcs()).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template in %q; error: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to evaluate template in %q; error: %w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
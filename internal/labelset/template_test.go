@@ -0,0 +1,165 @@
+package labelset
+
+// cSpell:ignore fstest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func TestResolver_Resolve_template(t *testing.T) {
+	fs := fstest.MapFS{
+		"labels.yml": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				include: team.csv
+				labels:
+				  - name: bug
+				    color: d73a4a
+				    description: Something isn't working in {{ .Owner }}/{{ .Repo }}
+				  - name: go
+				    color: 00add8
+				    if: {{ eq .Language "Go" }}
+				  - name: rust
+				    color: dea584
+				    if: {{ eq .Language "Rust" }}
+				{{- if has .Topics "CLI" }}
+				  - name: "area: cli"
+				    color: 0075ca
+				{{- end }}
+				  - name: security
+				    color: ee0701
+				    if: {{ ne .Visibility "public" }}
+			`)),
+		},
+		"team.csv": &fstest.MapFile{
+			Data: []byte(heredoc.Doc(`
+				name,color,description
+				{{ env "TEAM" "triage" | lower }},fbca04,Needs triage by the {{ .Env.ORG }} team
+			`)),
+		},
+		"plain.yml": &fstest.MapFile{
+			Data: []byte("labels: [{name: bug}]\n"),
+		},
+		"missing.yml": &fstest.MapFile{
+			Data: []byte("labels: [{name: '{{ .Env.MISSING }}'}]\n"),
+		},
+		"invalid.yml": &fstest.MapFile{
+			Data: []byte("labels: [{name: '{{ .Repo'}]\n"),
+		},
+	}
+
+	data := &TemplateData{
+		Owner:      "heaths",
+		Repo:       "gh-label",
+		Language:   "Go",
+		Visibility: "public",
+		Topics:     []string{"cli", "gh-extension"},
+		Env: map[string]string{
+			"ORG": "Contoso",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		dataErr   error
+		want      github.Labels
+		wantCalls int
+		wantE     bool
+	}{
+		{
+			name: "labels.yml",
+			want: github.Labels{
+				{Name: "triage", Color: "fbca04", Description: "Needs triage by the Contoso team"},
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working in heaths/gh-label"},
+				{Name: "go", Color: "00add8"},
+				{Name: "area: cli", Color: "0075ca"},
+			},
+			wantCalls: 1,
+		},
+		{
+			name: "plain.yml",
+			want: github.Labels{
+				{Name: "bug"},
+			},
+		},
+		{
+			name:      "missing.yml",
+			wantCalls: 1,
+			wantE:     true,
+		},
+		{
+			name:      "invalid.yml",
+			wantCalls: 1,
+			wantE:     true,
+		},
+		{
+			name:      "labels.yml",
+			dataErr:   errors.New("gh exited with code 1"),
+			wantCalls: 1,
+			wantE:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			r := &Resolver{
+				FS: fs,
+				Data: func() (*TemplateData, error) {
+					calls++
+					if tt.dataErr != nil {
+						return nil, tt.dataErr
+					}
+					return data, nil
+				},
+			}
+
+			got, err := r.Resolve(tt.name)
+			if (err != nil) != tt.wantE {
+				t.Errorf("Resolve() error = %v, expected error %v", err, tt.wantE)
+				return
+			}
+
+			if calls != tt.wantCalls {
+				t.Errorf("Resolve() called Data %d time(s), expected %d", calls, tt.wantCalls)
+			}
+
+			if !tt.wantE && !reflect.DeepEqual(got.Labels(), tt.want) {
+				t.Errorf("Resolve() = %v, expected %v", got.Labels(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEnviron(t *testing.T) {
+	t.Setenv("GH_LABEL_TEST", "a=b")
+	t.Setenv("GH_TOKEN", "secret")
+
+	env := Environ()
+	if got := env["TEST"]; got != "a=b" {
+		t.Errorf(`Environ()["TEST"] = %q, expected "a=b"`, got)
+	}
+
+	for name := range env {
+		if name == "GH_TOKEN" || name == "TOKEN" || name == "GH_LABEL_TEST" {
+			t.Errorf("Environ() contains %q", name)
+		}
+	}
+}
+
+func TestTemplateData_env(t *testing.T) {
+	data := &TemplateData{}
+
+	if _, err := data.execute("labels.yml", []byte(`{{ env "TEAM" "triage" }}`)); err == nil {
+		t.Error("execute() expected error when environment variables are not available")
+	}
+
+	if _, err := data.execute("labels.yml", []byte(`{{ .Env.TEAM }}`)); err == nil {
+		t.Error("execute() expected error when environment variables are not available")
+	}
+}
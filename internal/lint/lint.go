@@ -0,0 +1,313 @@
+package lint
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/heaths/gh-label/internal/github"
+	"github.com/heaths/gh-label/internal/utils"
+)
+
+// Kinds of violations.
+const (
+	ColorViolation             = "color"
+	ContrastViolation          = "contrast"
+	SimilarViolation           = "similar"
+	ScopeViolation             = "scope"
+	CharactersViolation        = "characters"
+	CaseViolation              = "case"
+	WhitespaceViolation        = "whitespace"
+	NameLengthViolation        = "name-length"
+	DescriptionViolation       = "description"
+	DescriptionLengthViolation = "description-length"
+	DuplicateViolation         = "duplicate"
+)
+
+// Violation describes a label that does not conform to Rules.
+type Violation struct {
+	Label   string       `json:"label"`
+	Color   string       `json:"color"`
+	Other   string       `json:"other,omitempty"`
+	Kind    string       `json:"kind"`
+	Vision  utils.Vision `json:"vision,omitempty"`
+	Value   float64      `json:"value,omitempty"`
+	Message string       `json:"message"`
+
+	// Fix is a safe change to the label that resolves the violation, if any.
+	Fix *github.EditLabel `json:"fix,omitempty"`
+}
+
+// Lint checks labels against rules and returns any violations in the order labels were passed.
+func Lint(labels github.Labels, rules *Rules) ([]Violation, error) {
+	if rules == nil {
+		rules = DefaultRules()
+	}
+
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	seen := make(map[string]string, len(labels))
+
+	// Count names without regard to case to avoid fixes that rename one label to another.
+	names := make(map[string]int, len(labels))
+	for _, label := range labels {
+		names[strings.ToLower(label.Name)]++
+	}
+
+	// Colors of other labels are compared only if valid.
+	var colored github.Labels
+
+	for _, label := range labels {
+		v := violation(label)
+		if !rules.ignored(label.Name) {
+			violations = append(violations, lintName(v, label, rules, names)...)
+		}
+		violations = append(violations, lintDescription(v, label, rules)...)
+
+		if label.Color == "" {
+			v := v
+			v.Kind = ColorViolation
+			v.Message = "color is not set"
+			violations = append(violations, v)
+		} else if _, err := utils.TextColor(label.Color); err != nil {
+			v := v
+			v.Kind = ColorViolation
+			v.Message = fmt.Sprintf("invalid color %q: %s", label.Color, err)
+			violations = append(violations, v)
+		} else {
+			violations = append(violations, lintContrast(v, label, rules)...)
+			colored = append(colored, label)
+		}
+
+		key := strings.ToLower(label.Name)
+		if other, ok := seen[key]; ok {
+			v.Other = other
+			v.Kind = DuplicateViolation
+			v.Message = fmt.Sprintf("name differs from %q only by case", other)
+			violations = append(violations, v)
+		} else {
+			seen[key] = label.Name
+		}
+	}
+
+	violations = append(violations, lintSimilar(colored, rules)...)
+
+	return violations, nil
+}
+
+// Fixes merges the fixes from violations into a single edit per label, sorted by label name.
+func Fixes(violations []Violation) []github.EditLabel {
+	edits := make(map[string]*github.EditLabel)
+	for _, v := range violations {
+		if v.Fix == nil {
+			continue
+		}
+
+		edit, ok := edits[v.Label]
+		if !ok {
+			edit = &github.EditLabel{
+				Label: github.Label{
+					Name: v.Label,
+				},
+			}
+			edits[v.Label] = edit
+		}
+
+		if v.Fix.NewName != "" {
+			// Apply name fixes in order, e.g. trimming whitespace then changing case.
+			edit.NewName = v.Fix.NewName
+		}
+		if v.Fix.Color != "" {
+			edit.Color = v.Fix.Color
+		}
+		if v.Fix.Description != "" {
+			edit.Description = v.Fix.Description
+		}
+	}
+
+	fixes := make([]github.EditLabel, 0, len(edits))
+	for _, edit := range edits {
+		fixes = append(fixes, *edit)
+	}
+	sort.Slice(fixes, func(i, j int) bool {
+		return fixes[i].Name < fixes[j].Name
+	})
+
+	return fixes
+}
+
+func violation(label github.Label) Violation {
+	return Violation{
+		Label: label.Name,
+		Color: label.Color,
+	}
+}
+
+// lintName checks the name of label after applying any fixes, so that fixed names are not reported again.
+// Names are counted in names to avoid fixes that rename a label to the name of another label.
+func lintName(v Violation, label github.Label, rules *Rules, names map[string]int) []Violation {
+	var violations []Violation
+	name := label.Name
+
+	rename := func(kind, message, newName string) {
+		fix := v
+		fix.Kind = kind
+		fix.Message = message
+
+		count := names[strings.ToLower(newName)]
+		if strings.EqualFold(newName, label.Name) {
+			count--
+		}
+		if count > 0 {
+			fix.Message += fmt.Sprintf("; not fixed because a label named %q already exists", newName)
+		} else {
+			fix.Fix = &github.EditLabel{NewName: newName}
+			name = newName
+		}
+
+		violations = append(violations, fix)
+	}
+
+	if trimmed := strings.TrimSpace(name); trimmed != name {
+		rename(WhitespaceViolation, "name has leading or trailing whitespace", trimmed)
+	}
+
+	if rules.Lowercase {
+		if lower := strings.ToLower(name); lower != name {
+			rename(CaseViolation, "name is not lowercase", lower)
+		}
+	}
+
+	if len(rules.Scopes) > 0 {
+		scoped := false
+		for _, scope := range rules.Scopes {
+			if strings.HasPrefix(name, scope+rules.ScopeSeparator) {
+				scoped = true
+				break
+			}
+		}
+
+		if !scoped {
+			v := v
+			v.Kind = ScopeViolation
+			v.Message = fmt.Sprintf("name does not start with a scope %v followed by %q", rules.Scopes, rules.ScopeSeparator)
+			violations = append(violations, v)
+		}
+	}
+
+	if rules.allowedCharacters != nil && !rules.allowedCharacters.MatchString(name) {
+		var invalid []string
+		for _, r := range name {
+			if s := string(r); !rules.allowedCharacters.MatchString(s) && !contains(invalid, s) {
+				invalid = append(invalid, s)
+			}
+		}
+
+		v := v
+		v.Kind = CharactersViolation
+		v.Message = fmt.Sprintf("name contains characters %q not in [%s]", strings.Join(invalid, ""), rules.AllowedCharacters)
+		violations = append(violations, v)
+	}
+
+	if n := utf8.RuneCountInString(name); rules.MaxNameLength > 0 && n > rules.MaxNameLength {
+		v := v
+		v.Kind = NameLengthViolation
+		v.Value = float64(n)
+		v.Message = fmt.Sprintf("name length %d is greater than %d", n, rules.MaxNameLength)
+		violations = append(violations, v)
+	}
+
+	return violations
+}
+
+func lintDescription(v Violation, label github.Label, rules *Rules) []Violation {
+	var violations []Violation
+	description := label.Description
+
+	if trimmed := strings.TrimSpace(description); trimmed != description && trimmed != "" {
+		fix := v
+		fix.Kind = WhitespaceViolation
+		fix.Message = "description has leading or trailing whitespace"
+		fix.Fix = &github.EditLabel{Label: github.Label{Description: trimmed}}
+		violations = append(violations, fix)
+		description = trimmed
+	}
+
+	if rules.RequireDescription && strings.TrimSpace(description) == "" {
+		v := v
+		v.Kind = DescriptionViolation
+		v.Message = "description is required"
+		violations = append(violations, v)
+	}
+
+	if n := utf8.RuneCountInString(description); n > rules.MaxDescriptionLength {
+		v := v
+		v.Kind = DescriptionLengthViolation
+		v.Value = float64(n)
+		v.Message = fmt.Sprintf("description length %d is greater than %d", n, rules.MaxDescriptionLength)
+		violations = append(violations, v)
+	}
+
+	return violations
+}
+
+func lintContrast(v Violation, label github.Label, rules *Rules) []Violation {
+	text, _ := utils.TextColor(label.Color)
+	ratio, _ := utils.ContrastRatio(label.Color, text)
+	if ratio < rules.MinContrast {
+		v.Kind = ContrastViolation
+		v.Value = round(ratio)
+		v.Message = fmt.Sprintf("contrast ratio %.2f with text #%s is less than %.2f", ratio, text, rules.MinContrast)
+		return []Violation{v}
+	}
+
+	return nil
+}
+
+func lintSimilar(labels github.Labels, rules *Rules) []Violation {
+	var violations []Violation
+
+	for i := 0; i < len(labels); i++ {
+		for j := i + 1; j < len(labels); j++ {
+			a, b := labels[i], labels[j]
+
+			// Report only the first vision where colors are too similar.
+			for _, vision := range utils.Visions() {
+				sa, _ := utils.SimulateVision(a.Color, vision)
+				sb, _ := utils.SimulateVision(b.Color, vision)
+
+				distance, _ := utils.ColorDistance(sa, sb)
+				if distance < rules.MinDistance {
+					v := violation(a)
+					v.Other = b.Name
+					v.Kind = SimilarViolation
+					v.Vision = vision
+					v.Value = round(distance)
+					v.Message = fmt.Sprintf("color difference %.2f from %q with %s vision is less than %.2f", distance, b.Name, vision, rules.MinDistance)
+					violations = append(violations, v)
+					break
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+func contains(arr []string, s string) bool {
+	for _, e := range arr {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,193 @@
+package lint
+
+// cSpell:ignoreRegExp /[0-9A-Fa-f]{6}/
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/heaths/gh-label/internal/github"
+)
+
+func TestLint(t *testing.T) {
+	noColors := func(r *Rules) *Rules {
+		r.MinContrast = 0
+		r.MinDistance = 0
+		return r
+	}
+
+	tests := []struct {
+		name   string
+		labels github.Labels
+		rules  *Rules
+		want   []string
+		wantE  bool
+	}{
+		{
+			name: "default rules",
+			labels: github.Labels{
+				{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+				{Name: "enhancement", Color: "a2eeef"},
+			},
+		},
+		{
+			name: "invalid colors",
+			labels: github.Labels{
+				{Name: "bug", Color: "red"},
+				{Name: "enhancement"},
+				{Name: "question", Color: "d876e3"},
+			},
+			rules: &Rules{MinDistance: 100},
+			want: []string{
+				"bug color",
+				"enhancement color",
+			},
+		},
+		{
+			name: "colors",
+			labels: github.Labels{
+				{Name: "bug", Color: "ff0000"},
+				{Name: "p1", Color: "fe0000"},
+			},
+			want: []string{
+				"bug contrast",
+				"p1 contrast",
+				"bug similar",
+			},
+		},
+		{
+			name: "names",
+			labels: github.Labels{
+				{Name: "type: bug", Color: "ffffff"},
+				{Name: " Type: Feature", Color: "ffffff"},
+				{Name: "area: really long label name", Color: "ffffff"},
+				{Name: "bug!", Color: "ffffff"},
+				{Name: "good first issue", Color: "ffffff"},
+			},
+			rules: noColors(&Rules{
+				Scopes:            []string{"area", "type"},
+				AllowedCharacters: "a-z :",
+				Lowercase:         true,
+				MaxNameLength:     20,
+				Ignore:            []string{"good first issue"},
+			}),
+			want: []string{
+				" Type: Feature whitespace",
+				" Type: Feature case",
+				"area: really long label name name-length",
+				"bug! scope",
+				"bug! characters",
+			},
+		},
+		{
+			name: "descriptions",
+			labels: github.Labels{
+				{Name: "bug", Color: "ffffff", Description: "Something isn't working "},
+				{Name: "enhancement", Color: "ffffff"},
+				{Name: "question", Color: "ffffff", Description: strings.Repeat("?", 101)},
+			},
+			rules: noColors(&Rules{
+				RequireDescription: true,
+			}),
+			want: []string{
+				"bug whitespace",
+				"enhancement description",
+				"question description-length",
+			},
+		},
+		{
+			name: "duplicates",
+			labels: github.Labels{
+				{Name: "bug", Color: "ffffff"},
+				{Name: "Bug", Color: "ffffff"},
+			},
+			rules: noColors(&Rules{}),
+			want: []string{
+				"Bug duplicate",
+			},
+		},
+		{
+			name: "colliding fixes",
+			labels: github.Labels{
+				{Name: "type: bug", Color: "ffffff"},
+				{Name: "Type: Bug", Color: "ffffff"},
+				{Name: "type: feature ", Color: "ffffff"},
+				{Name: "type: feature", Color: "ffffff"},
+			},
+			rules: noColors(&Rules{
+				Scopes:    []string{"type"},
+				Lowercase: true,
+			}),
+			want: []string{
+				"Type: Bug case",
+				"Type: Bug scope",
+				"Type: Bug duplicate",
+				"type: feature  whitespace",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := Lint(tt.labels, tt.rules)
+			if (err != nil) != tt.wantE {
+				t.Errorf("Lint() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.Label+" "+v.Kind)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %q, want %q", got, tt.want)
+			}
+
+			// Fixes never rename a label to the name of another label.
+			for _, fix := range Fixes(violations) {
+				for _, label := range tt.labels {
+					if label.Name != fix.Name && strings.EqualFold(label.Name, fix.NewName) {
+						t.Errorf("Fixes() renames %q to existing label %q", fix.Name, label.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFixes(t *testing.T) {
+	labels := github.Labels{
+		{Name: " Bug", Color: "ffffff", Description: " Something isn't working"},
+		{Name: "enhancement", Color: "ffffff"},
+		{Name: "Question", Color: "ffffff"},
+	}
+
+	violations, err := Lint(labels, &Rules{Lowercase: true, RequireDescription: true})
+	if err != nil {
+		t.Errorf("Lint() error = %v", err)
+		return
+	}
+
+	got := Fixes(violations)
+	want := []github.EditLabel{
+		{
+			Label: github.Label{
+				Name:        " Bug",
+				Description: "Something isn't working",
+			},
+			NewName: "bug",
+		},
+		{
+			Label: github.Label{
+				Name: "Question",
+			},
+			NewName: "question",
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fixes() = %v, want %v", got, want)
+	}
+}
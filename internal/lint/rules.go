@@ -0,0 +1,104 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/heaths/gh-label/internal/github"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxDescriptionLength is the maximum length of a label description GitHub allows.
+const MaxDescriptionLength = github.MaxDescriptionLength
+
+// Rules configures which checks Lint performs. The zero value of each rule disables it.
+type Rules struct {
+	// Scopes lists prefixes one of which every label name must start with, followed by ScopeSeparator.
+	Scopes []string `yaml:"scopes"`
+
+	// ScopeSeparator separates a scope from the rest of the label name. The default is ":".
+	ScopeSeparator string `yaml:"scope-separator"`
+
+	// AllowedCharacters is a regular expression character class, without brackets, every character in a name must match.
+	AllowedCharacters string `yaml:"allowed-characters"`
+
+	// Lowercase requires label names to be lowercase.
+	Lowercase bool `yaml:"lowercase"`
+
+	// MaxNameLength is the maximum number of characters in a label name.
+	MaxNameLength int `yaml:"max-name-length"`
+
+	// RequireDescription requires every label to have a description.
+	RequireDescription bool `yaml:"require-description"`
+
+	// MaxDescriptionLength is the maximum number of characters in a description. The default is GitHub's limit of 100.
+	MaxDescriptionLength int `yaml:"max-description-length"`
+
+	// MinContrast is the minimum WCAG contrast ratio between a label color and the text color GitHub renders on it.
+	MinContrast float64 `yaml:"min-contrast"`
+
+	// MinDistance is the minimum CIE76 color difference between any two label colors with normal or deficient color vision.
+	MinDistance float64 `yaml:"min-distance"`
+
+	// Ignore lists label names exempt from naming rules.
+	Ignore []string `yaml:"ignore"`
+
+	allowedCharacters *regexp.Regexp
+}
+
+// DefaultRules returns rules used when no rules file is specified.
+func DefaultRules() *Rules {
+	return &Rules{
+		ScopeSeparator:       ":",
+		MaxDescriptionLength: MaxDescriptionLength,
+		MinContrast:          4.5,
+		MinDistance:          10,
+	}
+}
+
+// ReadRules reads rules as YAML or JSON from r. Rules not specified use their default values.
+func ReadRules(r io.Reader) (*Rules, error) {
+	rules := DefaultRules()
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(rules); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read rules; error: %w", err)
+	}
+
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (rules *Rules) compile() error {
+	if rules.AllowedCharacters != "" && rules.allowedCharacters == nil {
+		re, err := regexp.Compile("^[" + rules.AllowedCharacters + "]*$")
+		if err != nil {
+			return fmt.Errorf("invalid rule allowed-characters %q; error: %w", rules.AllowedCharacters, err)
+		}
+		rules.allowedCharacters = re
+	}
+
+	if rules.ScopeSeparator == "" {
+		rules.ScopeSeparator = ":"
+	}
+
+	if rules.MaxDescriptionLength == 0 || rules.MaxDescriptionLength > MaxDescriptionLength {
+		rules.MaxDescriptionLength = MaxDescriptionLength
+	}
+
+	return nil
+}
+
+func (rules *Rules) ignored(name string) bool {
+	for _, ignore := range rules.Ignore {
+		if ignore == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestReadRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  func(*Rules) bool
+		wantE bool
+	}{
+		{
+			name: "empty",
+			want: func(r *Rules) bool {
+				return r.ScopeSeparator == ":" && r.MaxDescriptionLength == 100 && r.MinContrast == 4.5 && r.MinDistance == 10
+			},
+		},
+		{
+			name: "yaml",
+			data: heredoc.Doc(`
+				scopes: [area, type]
+				scope-separator: "/"
+				allowed-characters: "a-z/-"
+				lowercase: true
+				max-name-length: 20
+				require-description: true
+				max-description-length: 50
+				min-contrast: 3
+				min-distance: 0
+				ignore:
+				- good first issue
+			`),
+			want: func(r *Rules) bool {
+				return len(r.Scopes) == 2 && r.ScopeSeparator == "/" && r.allowedCharacters != nil && r.Lowercase &&
+					r.MaxNameLength == 20 && r.RequireDescription && r.MaxDescriptionLength == 50 &&
+					r.MinContrast == 3 && r.MinDistance == 0 && len(r.Ignore) == 1
+			},
+		},
+		{
+			name: "json",
+			data: `{"scopes": ["type"], "max-description-length": 200}`,
+			want: func(r *Rules) bool {
+				return len(r.Scopes) == 1 && r.MaxDescriptionLength == 100
+			},
+		},
+		{
+			name:  "unknown rule",
+			data:  "unknown: true",
+			wantE: true,
+		},
+		{
+			name:  "invalid characters",
+			data:  `allowed-characters: "\\"`,
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadRules(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantE {
+				t.Errorf("ReadRules() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if tt.want != nil && !tt.want(got) {
+				t.Errorf("ReadRules() = %+v", got)
+			}
+		})
+	}
+}
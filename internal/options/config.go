@@ -0,0 +1,147 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPaths are paths relative to the working directory or its ancestors in which configuration is discovered, in order.
+var ConfigPaths = []string{
+	filepath.Join(".github", "gh-label.yml"),
+	filepath.Join(".github", "gh-label.yaml"),
+}
+
+// Config contains default settings for commands. Command line flags override these settings.
+type Config struct {
+	// Source is the default label file to import, relative to the directory containing .github.
+	Source string `yaml:"source,omitempty"`
+
+	Import ImportConfig `yaml:"import"`
+	Export ExportConfig `yaml:"export"`
+
+	// path is the configuration file read, if any.
+	path string
+}
+
+// ImportConfig contains default settings for the import command.
+type ImportConfig struct {
+	// Prune deletes labels from the repository that were not imported.
+	// Prune and Keep apply only if the repository is resolved from the working directory,
+	// and labels are never pruned if any problems are found in the imported labels.
+	Prune bool `yaml:"prune"`
+
+	// Keep lists names or patterns like "area: *" of labels never to prune.
+	Keep []string `yaml:"keep,omitempty"`
+
+	// Concurrency is the number of labels to import at the same time.
+	Concurrency int `yaml:"concurrency"`
+}
+
+// ExportConfig contains default settings for the export command.
+type ExportConfig struct {
+	// Format is used when the format is not passed and cannot be determined from the file extension.
+	Format                string   `yaml:"format,omitempty"`
+	Fields                []string `yaml:"fields,omitempty"`
+	Sort                  bool     `yaml:"sort"`
+	ColorCase             string   `yaml:"color-case,omitempty"`
+	OmitURL               bool     `yaml:"omit-url"`
+	OmitEmptyDescriptions bool     `yaml:"omit-empty-descriptions"`
+}
+
+// DefaultConfig returns settings used when no configuration is discovered.
+func DefaultConfig() *Config {
+	return &Config{
+		Import: ImportConfig{
+			Concurrency: 1,
+		},
+	}
+}
+
+// Path returns the path of the configuration file read, or an empty string if none was discovered.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// SourcePath returns Source relative to the working directory, or an empty string if not set.
+func (c *Config) SourcePath(wd string) string {
+	if c.Source == "" || filepath.IsAbs(c.Source) || c.path == "" {
+		return c.Source
+	}
+
+	// Source is relative to the directory containing .github.
+	root := filepath.Dir(filepath.Dir(c.path))
+	if rel, err := filepath.Rel(wd, filepath.Join(root, c.Source)); err == nil {
+		return filepath.ToSlash(rel)
+	}
+
+	return filepath.Join(root, c.Source)
+}
+
+// ReadConfig reads configuration from r. Settings not specified use their default values.
+func ReadConfig(r io.Reader) (*Config, error) {
+	config := DefaultConfig()
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(config); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if config.Import.Concurrency < 1 {
+		return nil, fmt.Errorf("import concurrency must be at least 1, got %d", config.Import.Concurrency)
+	}
+
+	return config, nil
+}
+
+// findConfig returns the first of ConfigPaths found in dir or its ancestors, stopping at a directory containing .git.
+func findConfig(dir string) (string, error) {
+	for {
+		for _, p := range ConfigPaths {
+			path := filepath.Join(dir, p)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// loadConfig discovers and reads configuration from dir or its ancestors.
+func loadConfig(dir string) (*Config, error) {
+	path, err := findConfig(dir)
+	if err != nil || path == "" {
+		return DefaultConfig(), err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	config, err := ReadConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q; error: %w", path, err)
+	}
+
+	config.path = path
+	return config, nil
+}
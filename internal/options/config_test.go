@@ -0,0 +1,130 @@
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  *Config
+		wantE bool
+	}{
+		{
+			name: "empty",
+			want: DefaultConfig(),
+		},
+		{
+			name: "all settings",
+			data: heredoc.Doc(`
+				source: .github/labels.csv
+				import:
+				  prune: true
+				  keep:
+				    - "area: *"
+				  concurrency: 4
+				export:
+				  format: json
+				  fields: [name, color]
+				  sort: true
+				  color-case: lower
+				  omit-url: true
+				  omit-empty-descriptions: true
+			`),
+			want: &Config{
+				Source: ".github/labels.csv",
+				Import: ImportConfig{
+					Prune:       true,
+					Keep:        []string{"area: *"},
+					Concurrency: 4,
+				},
+				Export: ExportConfig{
+					Format:                "json",
+					Fields:                []string{"name", "color"},
+					Sort:                  true,
+					ColorCase:             "lower",
+					OmitURL:               true,
+					OmitEmptyDescriptions: true,
+				},
+			},
+		},
+		{
+			name:  "unknown setting",
+			data:  "export:\n  formats: json\n",
+			wantE: true,
+		},
+		{
+			name:  "invalid concurrency",
+			data:  "import:\n  concurrency: 0\n",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadConfig(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantE {
+				t.Errorf("ReadConfig() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if !tt.wantE && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadConfig(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(filepath.Join(root, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, ".github", "gh-label.yml")
+	if err := os.WriteFile(path, []byte("source: .github/labels.csv\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := loadConfig(sub)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	if got := config.Path(); got != path {
+		t.Errorf("Path() = %q, want %q", got, path)
+	}
+
+	if got, want := config.SourcePath(sub), "../../.github/labels.csv"; got != want {
+		t.Errorf("SourcePath() = %q, want %q", got, want)
+	}
+
+	// Discovery stops at the root of the git repository.
+	nested := filepath.Join(sub, "nested")
+	if err := os.MkdirAll(filepath.Join(nested, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err = loadConfig(nested)
+	if err != nil {
+		t.Fatalf("loadConfig() error = %v", err)
+	}
+
+	if got := config.Path(); got != "" {
+		t.Errorf("Path() = %q, want none", got)
+	}
+}
@@ -1,10 +1,15 @@
 package options
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/git"
+	"github.com/heaths/gh-label/internal/github"
 	"github.com/spf13/cobra"
 )
 
@@ -12,12 +17,50 @@ type keyStore interface {
 	get(key string) string
 }
 
+type remoteLister interface {
+	Remotes() ([]git.Remote, error)
+}
+
+type repoSearcher interface {
+	SearchRepositories(query string) ([]string, error)
+}
+
+// MultiRepoAnnotation is a command annotation set to "true" for commands that call ForEachRepo.
+// Other commands return an error if more than one repository is selected.
+const MultiRepoAnnotation = "multiRepo"
+
+// OfflineAnnotation is a command annotation set to "true" for commands that may not need a repository, like those
+// that only read files. The repository is not resolved from git remotes until ResolveRepo is called.
+const OfflineAnnotation = "offline"
+
+// Target is a selected repository.
+type Target struct {
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// String returns "OWNER/REPO", prefixed with "HOST/" for hosts other than github.com.
+func (t Target) String() string {
+	return fullName(t.Host, t.Owner, t.Repo)
+}
+
 type GlobalOptions struct {
-	owner string
-	repo  string
+	host    string
+	owner   string
+	repo    string
+	targets []Target
+	config  *Config
+	local   bool
+	offline bool
+	pending bool
 
 	// test
-	keys keyStore
+	keys    keyStore
+	dir     string
+	remotes remoteLister
+	search  repoSearcher
+	io      *iostreams.IOStreams
 }
 
 func New(cmd *cobra.Command) *GlobalOptions {
@@ -25,50 +68,373 @@ func New(cmd *cobra.Command) *GlobalOptions {
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
 
-		repoOverride, _ := cmd.Flags().GetString("repo")
-		return opts.parseRepoOverride(repoOverride)
+		opts.offline = cmd.Annotations[OfflineAnnotation] == "true"
+
+		repoOverrides, _ := cmd.Flags().GetStringArray("repo")
+		reposFile, _ := cmd.Flags().GetString("repos-file")
+		repoQuery, _ := cmd.Flags().GetString("repo-query")
+		if err := opts.parseTargets(repoOverrides, reposFile, repoQuery); err != nil {
+			return err
+		}
+
+		if len(opts.targets) > 1 && cmd.Annotations[MultiRepoAnnotation] != "true" {
+			return fmt.Errorf("%q does not support more than one repository, got %d", cmd.CommandPath(), len(opts.targets))
+		}
+
+		return opts.loadConfig()
 	}
 
-	cmd.PersistentFlags().StringP("repo", "R", "", "Select another repository using the `OWNER/REPO` format")
+	cmd.PersistentFlags().StringArrayP("repo", "R", nil, "Select another repository using the `[HOST/]OWNER/REPO` format or a URL. Repeat for more repositories.")
+	cmd.PersistentFlags().String("repos-file", "", "Select repositories listed one per line in a `file`, or stdin if \"-\".")
+	cmd.PersistentFlags().String("repo-query", "", "Select repositories matching a search `query` like \"org:heaths topic:gh-extension\".")
 
 	return opts
 }
 
+// Targets returns the selected repositories. A single repository may be unresolved with owner ":owner" and repo ":repo".
+func (opts *GlobalOptions) Targets() []Target {
+	if len(opts.targets) == 0 {
+		return []Target{{opts.host, opts.owner, opts.repo}}
+	}
+	return opts.targets
+}
+
+// ForEachRepo calls fn with options for each selected repository in turn.
+// If more than one repository is selected, a report of which repositories failed is written to stderr,
+// and an error is returned if any failed.
+func (opts *GlobalOptions) ForEachRepo(fn func(opts *GlobalOptions) error) error {
+	targets := opts.Targets()
+	if len(targets) == 1 {
+		return fn(opts)
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	var failures []string
+	for i, target := range targets {
+		if opts.io.IsStderrTTY() {
+			if i > 0 {
+				fmt.Fprintln(opts.io.ErrOut)
+			}
+			fmt.Fprintf(opts.io.ErrOut, "Operating on %s\n", target)
+		}
+
+		repoOpts := *opts
+		repoOpts.host = target.Host
+		repoOpts.owner = target.Owner
+		repoOpts.repo = target.Repo
+		repoOpts.targets = nil
+
+		if err := fn(&repoOpts); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", target, err))
+		}
+	}
+
+	fmt.Fprintf(opts.io.ErrOut, "\nSucceeded in %d of %d repositories\n", len(targets)-len(failures), len(targets))
+	for _, failure := range failures {
+		fmt.Fprintf(opts.io.ErrOut, "Failed in %s\n", failure)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed in %d of %d repositories", len(failures), len(targets))
+	}
+
+	return nil
+}
+
 func (opts *GlobalOptions) Repo() (owner, repo string) {
 	return opts.owner, opts.repo
 }
 
+// Host returns the GitHub hostname of the repository, or an empty string to use the host gh is authenticated to.
+func (opts *GlobalOptions) Host() string {
+	return opts.host
+}
+
+// Config returns settings discovered in the working directory or its ancestors, or default settings.
+func (opts *GlobalOptions) Config() *Config {
+	if opts.config == nil {
+		opts.config = DefaultConfig()
+	}
+	return opts.config
+}
+
+// ResolveRepo resolves the repository from git remotes for commands with OfflineAnnotation that need a repository.
+// It does nothing if the repository was already resolved or selected.
+func (opts *GlobalOptions) ResolveRepo() error {
+	if !opts.pending {
+		return nil
+	}
+
+	opts.pending = false
+	return opts.resolveRemote()
+}
+
+// Local returns whether the repository was resolved from the working directory rather than selected by
+// --repo, --repos-file, --repo-query, or GH_REPO. Settings from Config that change a repository apply only if true.
+func (opts *GlobalOptions) Local() bool {
+	return opts.local
+}
+
+// Dir returns the working directory.
+func (opts *GlobalOptions) Dir() string {
+	if opts.dir == "" {
+		opts.dir, _ = os.Getwd()
+	}
+	return opts.dir
+}
+
+func (opts *GlobalOptions) loadConfig() (err error) {
+	opts.config, err = loadConfig(opts.Dir())
+	return
+}
+
+// parseTargets selects repositories passed to --repo, listed in reposFile, and matching repoQuery.
+// If none are passed, a single repository is selected from GH_REPO or git remotes.
+func (opts *GlobalOptions) parseTargets(repoOverrides []string, reposFile, repoQuery string) error {
+	if opts.keys == nil {
+		opts.keys = &environment{}
+	}
+
+	if reposFile != "" {
+		names, err := opts.readReposFile(reposFile)
+		if err != nil {
+			return err
+		}
+		repoOverrides = append(repoOverrides, names...)
+	}
+
+	if repoQuery != "" {
+		if opts.search == nil {
+			opts.search = github.New(&github.Cli{Host: opts.keys.get("GH_HOST")})
+		}
+
+		names, err := opts.search.SearchRepositories(repoQuery)
+		if err != nil {
+			return fmt.Errorf("failed to search repositories; error: %w", err)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no repositories match %q", repoQuery)
+		}
+		repoOverrides = append(repoOverrides, names...)
+	}
+
+	if len(repoOverrides) == 0 {
+		return opts.parseRepoOverride("")
+	}
+
+	opts.targets = nil
+	seen := make(map[string]bool)
+	for _, repoOverride := range repoOverrides {
+		if err := opts.parseRepoOverride(repoOverride); err != nil {
+			return err
+		}
+
+		target := Target{opts.host, opts.owner, opts.repo}
+		if key := strings.ToLower(target.String()); !seen[key] {
+			seen[key] = true
+			opts.targets = append(opts.targets, target)
+		}
+	}
+
+	// Repo and Host return the first repository.
+	first := opts.targets[0]
+	opts.host, opts.owner, opts.repo = first.Host, first.Owner, first.Repo
+
+	return nil
+}
+
+// readReposFile reads repositories listed one per line, ignoring blank lines and comments starting with "#".
+func (opts *GlobalOptions) readReposFile(path string) ([]string, error) {
+	var r io.Reader
+	if path == "-" {
+		if opts.io == nil {
+			opts.io = iostreams.System()
+		}
+		r = opts.io.In
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file %q; error: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file %q; error: %w", path, err)
+	}
+
+	return names, nil
+}
+
 func (opts *GlobalOptions) parseRepoOverride(repoOverride string) error {
+	if opts.keys == nil {
+		opts.keys = &environment{}
+	}
+
 	if len(repoOverride) == 0 {
-		if opts.keys == nil {
-			opts.keys = &environment{}
-		}
 		repoOverride = opts.keys.get("GH_REPO")
 	}
 
-	if len(repoOverride) == 0 {
-		opts.owner = ":owner"
-		opts.repo = ":repo"
+	// GH_HOST is used unless the repository specifies a host.
+	opts.host = opts.keys.get("GH_HOST")
+
+	opts.local = len(repoOverride) == 0
+	if opts.local {
+		if opts.offline {
+			// Like resolveRemote without remotes until ResolveRepo is called.
+			opts.owner = ":owner"
+			opts.repo = ":repo"
+			opts.pending = true
+			return nil
+		}
+		return opts.resolveRemote()
+	}
+
+	if strings.Contains(repoOverride, "://") {
+		host, owner, repo, ok := git.Remote{URL: repoOverride}.Repo()
+		if !ok {
+			return fmt.Errorf(`expected a URL like "https://HOST/OWNER/REPO", got %s`, repoOverride)
+		}
+
+		opts.host = host
+		opts.owner = owner
+		opts.repo = repo
 		return nil
 	}
 
 	parts := strings.Split(repoOverride, "/")
 
-	if len(parts) != 2 {
-		return fmt.Errorf(`expected the "OWNER/REPO" format, got %s`, repoOverride)
+	if len(parts) != 2 && len(parts) != 3 {
+		return fmt.Errorf(`expected the "[HOST/]OWNER/REPO" format, got %s`, repoOverride)
 	}
 
 	for _, part := range parts {
 		if part == "" {
-			return fmt.Errorf(`expected the "OWNER/REPO" format, got %s`, repoOverride)
+			return fmt.Errorf(`expected the "[HOST/]OWNER/REPO" format, got %s`, repoOverride)
 		}
 	}
 
+	if len(parts) == 3 {
+		opts.host = strings.ToLower(parts[0])
+		parts = parts[1:]
+	}
+
 	opts.owner = parts[0]
 	opts.repo = parts[1]
 	return nil
 }
 
+// resolveRemote resolves the repository from git remotes: first the remote chosen by "gh repo set-default",
+// then "upstream", then "origin", or the only repository any remotes reference.
+// If there are no remotes, gh resolves the placeholders ":owner" and ":repo" itself.
+func (opts *GlobalOptions) resolveRemote() error {
+	if opts.remotes == nil {
+		opts.remotes = &git.Cli{Dir: opts.dir}
+	}
+
+	if opts.io == nil {
+		opts.io = iostreams.System()
+	}
+
+	opts.owner = ":owner"
+	opts.repo = ":repo"
+
+	remotes, err := opts.remotes.Remotes()
+	if err != nil {
+		// Not a git repository or git is not installed.
+		return nil
+	}
+
+	type candidate struct {
+		remote            string
+		host, owner, repo string
+	}
+
+	var candidates []candidate
+	var resolved *candidate
+	for _, remote := range remotes {
+		host, owner, repo, ok := remote.Repo()
+		if !ok {
+			continue
+		}
+
+		c := candidate{remote.Name, host, owner, repo}
+		candidates = append(candidates, c)
+
+		if remote.Resolved == "base" {
+			resolved = &c
+		} else if parts := strings.Split(remote.Resolved, "/"); len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+			resolved = &candidate{remote.Name, host, parts[0], parts[1]}
+		}
+	}
+
+	if resolved == nil {
+		for _, name := range []string{"upstream", "origin"} {
+			for i := range candidates {
+				if candidates[i].remote == name {
+					resolved = &candidates[i]
+					break
+				}
+			}
+			if resolved != nil {
+				break
+			}
+		}
+	}
+
+	if resolved == nil && len(candidates) > 0 {
+		resolved = &candidates[0]
+		for _, c := range candidates[1:] {
+			if c.host != resolved.host || !strings.EqualFold(c.owner, resolved.owner) || !strings.EqualFold(c.repo, resolved.repo) {
+				var sb strings.Builder
+				for _, c := range candidates {
+					fmt.Fprintf(&sb, "\n  %s\t%s", c.remote, fullName(c.host, c.owner, c.repo))
+				}
+				return fmt.Errorf(`cannot determine the repository from git remotes; pass --repo or run "gh repo set-default" with one of:%s`, sb.String())
+			}
+		}
+	}
+
+	if resolved == nil {
+		return nil
+	}
+
+	opts.host = resolved.host
+	opts.owner = resolved.owner
+	opts.repo = resolved.repo
+
+	if opts.io.IsStderrTTY() {
+		fmt.Fprintf(opts.io.ErrOut, "Operating on %s\n", fullName(resolved.host, resolved.owner, resolved.repo))
+	}
+
+	return nil
+}
+
+// fullName returns "OWNER/REPO", prefixed with "HOST/" for hosts other than github.com.
+func fullName(host, owner, repo string) string {
+	if host == "" || host == "github.com" {
+		return owner + "/" + repo
+	}
+	return host + "/" + owner + "/" + repo
+}
+
 type environment struct{}
 
 func (env *environment) get(key string) string {
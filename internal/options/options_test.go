@@ -1,6 +1,16 @@
 package options
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/git"
+	"github.com/spf13/cobra"
+)
 
 func Test_RepoOverride(t *testing.T) {
 	opts := GlobalOptions{
@@ -15,13 +25,17 @@ func Test_RepoOverride(t *testing.T) {
 
 func Test_parseRepoOverride(t *testing.T) {
 	type args struct {
-		args string
-		env  map[string]string
+		args    string
+		env     map[string]string
+		remotes []git.Remote
+		tty     bool
 	}
 
 	type want struct {
-		owner string
-		repo  string
+		host   string
+		owner  string
+		repo   string
+		stderr string
 	}
 
 	tests := []struct {
@@ -37,6 +51,113 @@ func Test_parseRepoOverride(t *testing.T) {
 				repo:  ":repo",
 			},
 		},
+		{
+			name: "origin",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "origin", URL: "git@github.com:heaths/gh-label.git"},
+				},
+			},
+			want: want{
+				host:  "github.com",
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "upstream before origin (TTY)",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "origin", URL: "https://github.com/fork/gh-label.git"},
+					{Name: "upstream", URL: "https://github.com/heaths/gh-label"},
+				},
+				tty: true,
+			},
+			want: want{
+				host:   "github.com",
+				owner:  "heaths",
+				repo:   "gh-label",
+				stderr: "Operating on heaths/gh-label\n",
+			},
+		},
+		{
+			name: "set default",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "origin", URL: "https://github.com/fork/gh-label.git", Resolved: "base"},
+					{Name: "upstream", URL: "https://github.com/heaths/gh-label"},
+				},
+			},
+			want: want{
+				host:  "github.com",
+				owner: "fork",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "set default to another repo",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "origin", URL: "https://github.com/fork/gh-label.git", Resolved: "heaths/gh-label"},
+				},
+			},
+			want: want{
+				host:  "github.com",
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "same repo",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "github", URL: "ssh://git@github.com/heaths/gh-label.git"},
+					{Name: "mirror", URL: "https://github.com/heaths/gh-label"},
+				},
+			},
+			want: want{
+				host:  "github.com",
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "ambiguous",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "fork", URL: "https://github.com/fork/gh-label"},
+					{Name: "main", URL: "https://github.com/heaths/gh-label"},
+				},
+			},
+			wantE: true,
+		},
+		{
+			name: "local remote",
+			args: args{
+				remotes: []git.Remote{
+					{Name: "origin", URL: "/src/gh-label"},
+				},
+			},
+			want: want{
+				owner: ":owner",
+				repo:  ":repo",
+			},
+		},
+		{
+			name: "environment before remotes",
+			args: args{
+				env: map[string]string{
+					"GH_REPO": "heaths/gh-label",
+				},
+				remotes: []git.Remote{
+					{Name: "origin", URL: "https://github.com/fork/gh-label"},
+				},
+			},
+			want: want{
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
 		{
 			name: "from environment",
 			args: args{
@@ -56,10 +177,71 @@ func Test_parseRepoOverride(t *testing.T) {
 			},
 			wantE: true,
 		},
+		{
+			name: "with host",
+			args: args{
+				args: "GHE.example.com/heaths/gh-label",
+			},
+			want: want{
+				host:  "ghe.example.com",
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "url",
+			args: args{
+				args: "https://ghe.example.com/heaths/gh-label.git",
+			},
+			want: want{
+				host:  "ghe.example.com",
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "url without repo",
+			args: args{
+				args: "https://ghe.example.com/heaths",
+			},
+			wantE: true,
+		},
+		{
+			name: "host from environment",
+			args: args{
+				args: "heaths/gh-label",
+				env: map[string]string{
+					"GH_HOST": "ghe.example.com",
+				},
+			},
+			want: want{
+				host:  "ghe.example.com",
+				owner: "heaths",
+				repo:  "gh-label",
+			},
+		},
+		{
+			name: "host from remote (TTY)",
+			args: args{
+				env: map[string]string{
+					"GH_HOST": "github.com",
+				},
+				remotes: []git.Remote{
+					{Name: "origin", URL: "git@ghe.example.com:heaths/gh-label.git"},
+				},
+				tty: true,
+			},
+			want: want{
+				host:   "ghe.example.com",
+				owner:  "heaths",
+				repo:   "gh-label",
+				stderr: "Operating on ghe.example.com/heaths/gh-label\n",
+			},
+		},
 		{
 			name: "too many slashes",
 			args: args{
-				args: "github.com/heaths/gh-label",
+				args: "github.com/heaths/gh-label/labels",
 			},
 			wantE: true,
 		},
@@ -74,10 +256,17 @@ func Test_parseRepoOverride(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			io, _, _, stderr := iostreams.Test()
+			io.SetStderrTTY(tt.args.tty)
+
 			opts := GlobalOptions{
 				keys: &mockStore{
 					env: tt.args.env,
 				},
+				remotes: &mockRemotes{
+					remotes: tt.args.remotes,
+				},
+				io: io,
 			}
 
 			if err := opts.parseRepoOverride(tt.args.args); (err != nil) != tt.wantE {
@@ -85,6 +274,15 @@ func Test_parseRepoOverride(t *testing.T) {
 				return
 			}
 
+			if tt.wantE {
+				return
+			}
+
+			if opts.host != tt.want.host {
+				t.Errorf("parseRepoOverride() host = %q, want %q", opts.host, tt.want.host)
+				return
+			}
+
 			if opts.owner != tt.want.owner {
 				t.Errorf("parseRepoOverride() owner = %q, want %q", opts.owner, tt.want.owner)
 				return
@@ -93,6 +291,10 @@ func Test_parseRepoOverride(t *testing.T) {
 			if opts.repo != tt.want.repo {
 				t.Errorf("parseRepoOverride() repo = %q, want %q", opts.repo, tt.want.repo)
 			}
+
+			if got := stderr.String(); got != tt.want.stderr {
+				t.Errorf("parseRepoOverride() stderr = %q, want %q", got, tt.want.stderr)
+			}
 		})
 	}
 }
@@ -104,3 +306,305 @@ type mockStore struct {
 func (m *mockStore) get(key string) string {
 	return m.env[key]
 }
+
+type mockRemotes struct {
+	remotes []git.Remote
+}
+
+func (m *mockRemotes) Remotes() ([]git.Remote, error) {
+	return m.remotes, nil
+}
+
+func Test_parseTargets(t *testing.T) {
+	type args struct {
+		repos     []string
+		reposFile string
+		query     string
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		want  []Target
+		local bool
+		wantE bool
+	}{
+		{
+			name: "none",
+			want: []Target{
+				{"", ":owner", ":repo"},
+			},
+			local: true,
+		},
+		{
+			name: "single",
+			args: args{
+				repos: []string{"heaths/gh-label"},
+			},
+			want: []Target{
+				{"", "heaths", "gh-label"},
+			},
+		},
+		{
+			name: "repeated",
+			args: args{
+				repos: []string{"heaths/gh-label", "ghe.example.com/heaths/gh-label", "HEATHS/GH-LABEL"},
+			},
+			want: []Target{
+				{"", "heaths", "gh-label"},
+				{"ghe.example.com", "heaths", "gh-label"},
+			},
+		},
+		{
+			name: "file",
+			args: args{
+				repos: []string{"heaths/gh-label"},
+				reposFile: heredoc.Doc(`
+					# Extensions
+					heaths/gh-projects
+
+					heaths/go-console # Library
+				`),
+			},
+			want: []Target{
+				{"", "heaths", "gh-label"},
+				{"", "heaths", "gh-projects"},
+				{"", "heaths", "go-console"},
+			},
+		},
+		{
+			name: "query",
+			args: args{
+				query: "user:heaths topic:gh-extension",
+			},
+			want: []Target{
+				{"", "heaths", "gh-label"},
+				{"", "heaths", "gh-projects"},
+			},
+		},
+		{
+			name: "invalid",
+			args: args{
+				repos: []string{"heaths/gh-label", "heaths"},
+			},
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			io, stdin, _, _ := iostreams.Test()
+			stdin.WriteString(tt.args.reposFile)
+
+			reposFile := ""
+			if tt.args.reposFile != "" {
+				reposFile = "-"
+			}
+
+			opts := GlobalOptions{
+				keys:    &mockStore{},
+				remotes: &mockRemotes{},
+				search: &mockSearcher{
+					names: []string{"heaths/gh-label", "heaths/gh-projects"},
+				},
+				io: io,
+			}
+
+			if err := opts.parseTargets(tt.args.repos, reposFile, tt.args.query); (err != nil) != tt.wantE {
+				t.Errorf("parseTargets() error = %v, wantE %v", err, tt.wantE)
+				return
+			}
+
+			if tt.wantE {
+				return
+			}
+
+			if got := opts.Targets(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Targets() = %v, want %v", got, tt.want)
+			}
+
+			if owner, repo := opts.Repo(); owner != tt.want[0].Owner || repo != tt.want[0].Repo {
+				t.Errorf("Repo() = (%s, %s), want first target %v", owner, repo, tt.want[0])
+			}
+
+			if got := opts.Local(); got != tt.local {
+				t.Errorf("Local() = %v, want %v", got, tt.local)
+			}
+		})
+	}
+}
+
+func TestGlobalOptions_ForEachRepo(t *testing.T) {
+	io, _, _, stderr := iostreams.Test()
+	opts := GlobalOptions{
+		targets: []Target{
+			{"", "heaths", "gh-label"},
+			{"ghe.example.com", "heaths", "gh-label"},
+			{"", "heaths", "go-console"},
+		},
+		io: io,
+	}
+
+	var got []string
+	err := opts.ForEachRepo(func(opts *GlobalOptions) error {
+		owner, repo := opts.Repo()
+		got = append(got, opts.Host()+"/"+owner+"/"+repo)
+
+		if len(opts.Targets()) != 1 {
+			t.Errorf("Targets() = %v, want only the current repository", opts.Targets())
+		}
+
+		if repo == "go-console" {
+			return errors.New("failed to create label")
+		}
+		return nil
+	})
+
+	if err == nil {
+		t.Error("ForEachRepo() expected error")
+	}
+
+	want := []string{"/heaths/gh-label", "ghe.example.com/heaths/gh-label", "/heaths/go-console"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForEachRepo() called with %v, want %v", got, want)
+	}
+
+	wantW := heredoc.Doc(`
+
+		Succeeded in 2 of 3 repositories
+		Failed in heaths/go-console: failed to create label
+	`)
+	if gotW := stderr.String(); gotW != wantW {
+		t.Errorf("ForEachRepo() stderr = %q, want %q", gotW, wantW)
+	}
+}
+
+type mockSearcher struct {
+	names []string
+}
+
+func (m *mockSearcher) SearchRepositories(query string) ([]string, error) {
+	return m.names, nil
+}
+
+func TestNew_multiRepoAnnotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantE       bool
+	}{
+		{
+			name: "supported",
+			annotations: map[string]string{
+				MultiRepoAnnotation: "true",
+			},
+		},
+		{
+			name:  "unsupported",
+			wantE: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_REPO", "")
+
+			root := &cobra.Command{Use: "label"}
+			opts := New(root)
+			opts.dir = t.TempDir()
+
+			root.AddCommand(&cobra.Command{
+				Use:         "test",
+				Annotations: tt.annotations,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					return nil
+				},
+			})
+			root.SetArgs([]string{"test", "--repo", "heaths/gh-label", "-R", "heaths/go-console"})
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+
+			if err := root.Execute(); (err != nil) != tt.wantE {
+				t.Errorf("Execute() error = %v, wantE %v", err, tt.wantE)
+			}
+		})
+	}
+}
+
+func TestNew_offlineAnnotation(t *testing.T) {
+	// Remotes referencing different repositories cannot be resolved.
+	remotes := &mockRemotes{
+		remotes: []git.Remote{
+			{Name: "fork", URL: "https://github.com/someone/gh-label.git"},
+			{Name: "other", URL: "https://github.com/heaths/gh-label.git"},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		resolve     bool
+		wantE       bool
+	}{
+		{
+			name:  "online",
+			wantE: true,
+		},
+		{
+			name: "offline",
+			annotations: map[string]string{
+				OfflineAnnotation: "true",
+			},
+		},
+		{
+			name: "offline resolved",
+			annotations: map[string]string{
+				OfflineAnnotation: "true",
+			},
+			resolve: true,
+			wantE:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GH_REPO", "")
+
+			io, _, _, stderr := iostreams.Test()
+			io.SetStderrTTY(true)
+
+			root := &cobra.Command{Use: "label"}
+			opts := New(root)
+			opts.dir = t.TempDir()
+			opts.remotes = remotes
+			opts.io = io
+
+			root.AddCommand(&cobra.Command{
+				Use:         "test",
+				Annotations: tt.annotations,
+				RunE: func(cmd *cobra.Command, args []string) error {
+					if tt.resolve {
+						return opts.ResolveRepo()
+					}
+					return nil
+				},
+			})
+			root.SetArgs([]string{"test"})
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+
+			if err := root.Execute(); (err != nil) != tt.wantE {
+				t.Errorf("Execute() error = %v, wantE %v", err, tt.wantE)
+			}
+
+			if !tt.wantE {
+				if owner, repo := opts.Repo(); owner != ":owner" || repo != ":repo" {
+					t.Errorf("Repo() = (%s, %s), want unresolved", owner, repo)
+				}
+				if got := stderr.String(); got != "" {
+					t.Errorf("stderr = %q, want none", got)
+				}
+			}
+		})
+	}
+}
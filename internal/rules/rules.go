@@ -0,0 +1,294 @@
+// Package rules evaluates rules that map conditions on issues and pull requests to labels.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is the rules file read if no other file is specified.
+const DefaultPath = ".github/label-auto.yml"
+
+// Subject is an issue or pull request to evaluate.
+type Subject struct {
+	Title             string
+	Body              string
+	AuthorAssociation string
+	PullRequest       bool
+
+	// Branch is the head branch of a pull request.
+	Branch string
+
+	// Files are paths of files changed by a pull request.
+	Files []string
+
+	// Labels are names of labels already applied.
+	Labels []string
+}
+
+// Rule adds Labels to a Subject if all its conditions match.
+type Rule struct {
+	Labels []string
+
+	title              *regexp.Regexp
+	body               *regexp.Regexp
+	authorAssociations []string
+	files              []*regexp.Regexp
+	branch             *regexp.Regexp
+	kind               string
+}
+
+// Rules are evaluated in order.
+type Rules []Rule
+
+// ruleDef is a rule as it is written in a rules file.
+type ruleDef struct {
+	Labels            []string `yaml:"labels"`
+	Title             string   `yaml:"title"`
+	Body              string   `yaml:"body"`
+	AuthorAssociation []string `yaml:"author-association"`
+	Files             []string `yaml:"files"`
+	Branch            string   `yaml:"branch"`
+	Type              string   `yaml:"type"`
+}
+
+const (
+	issueKind       = "issue"
+	pullRequestKind = "pull-request"
+)
+
+// Read reads rules from a YAML file like:
+//
+//	rules:
+//	  - labels: [bug]
+//	    title: "(?i)crash|panic"
+//	  - labels: [documentation]
+//	    files: ["docs/**", "*.md"]
+//
+// Each rule must have at least one label and one condition.
+func Read(r io.Reader) (Rules, error) {
+	var file struct {
+		Rules []ruleDef `yaml:"rules"`
+	}
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	rules := make(Rules, 0, len(file.Rules))
+	for i, def := range file.Rules {
+		rule, err := def.compile()
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (def ruleDef) compile() (rule Rule, err error) {
+	if len(def.Labels) == 0 {
+		return rule, errors.New("labels are required")
+	}
+	for _, label := range def.Labels {
+		if strings.TrimSpace(label) == "" {
+			return rule, errors.New("labels must not be empty")
+		}
+	}
+	rule.Labels = def.Labels
+
+	if def.Title == "" && def.Body == "" && len(def.AuthorAssociation) == 0 && len(def.Files) == 0 && def.Branch == "" && def.Type == "" {
+		return rule, errors.New("at least one condition is required")
+	}
+
+	if rule.title, err = compile("title", def.Title); err != nil {
+		return
+	}
+	if rule.body, err = compile("body", def.Body); err != nil {
+		return
+	}
+	if rule.branch, err = compile("branch", def.Branch); err != nil {
+		return
+	}
+
+	for _, association := range def.AuthorAssociation {
+		rule.authorAssociations = append(rule.authorAssociations, strings.ToUpper(association))
+	}
+
+	for _, pattern := range def.Files {
+		re, err := compileGlob(pattern)
+		if err != nil {
+			return rule, fmt.Errorf("files: invalid pattern %q", pattern)
+		}
+		rule.files = append(rule.files, re)
+	}
+
+	switch strings.ToLower(def.Type) {
+	case "":
+	case issueKind:
+		rule.kind = issueKind
+	case pullRequestKind, "pr":
+		rule.kind = pullRequestKind
+	default:
+		return rule, fmt.Errorf("type: expected %q or %q, got %q", issueKind, pullRequestKind, def.Type)
+	}
+
+	return rule, nil
+}
+
+func compile(field, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field, err)
+	}
+	return re, nil
+}
+
+// compileGlob compiles a pattern where "**" matches any number of directories, "*" and "?" match within a directory,
+// and character classes are like path.Match. Patterns without a "/" match the base name of files in any directory.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !strings.Contains(pattern, "/") {
+		sb.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				sb.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(pattern[i:], "**") {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			// Classes were validated by path.Match, which uses the same syntax.
+			j := strings.IndexByte(pattern[i+1:], ']') + i + 1
+			sb.WriteString(pattern[i : j+1])
+			i = j
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// NeedsPullRequest returns whether any rule matches the branch or files of pull requests.
+func (rules Rules) NeedsPullRequest() bool {
+	for _, rule := range rules {
+		if rule.branch != nil || len(rule.files) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate returns labels from all rules matching s, in order and without duplicates or labels already applied to s.
+func (rules Rules) Evaluate(s Subject) []string {
+	seen := make(map[string]bool)
+	for _, label := range s.Labels {
+		seen[strings.ToLower(label)] = true
+	}
+
+	var labels []string
+	for _, rule := range rules {
+		if !rule.Matches(s) {
+			continue
+		}
+
+		for _, label := range rule.Labels {
+			if key := strings.ToLower(label); !seen[key] {
+				seen[key] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	return labels
+}
+
+// Matches returns whether all conditions of the rule match s.
+func (rule Rule) Matches(s Subject) bool {
+	switch rule.kind {
+	case issueKind:
+		if s.PullRequest {
+			return false
+		}
+	case pullRequestKind:
+		if !s.PullRequest {
+			return false
+		}
+	}
+
+	if rule.title != nil && !rule.title.MatchString(s.Title) {
+		return false
+	}
+
+	if rule.body != nil && !rule.body.MatchString(s.Body) {
+		return false
+	}
+
+	if len(rule.authorAssociations) > 0 && !contains(rule.authorAssociations, strings.ToUpper(s.AuthorAssociation)) {
+		return false
+	}
+
+	if rule.branch != nil && (!s.PullRequest || !rule.branch.MatchString(s.Branch)) {
+		return false
+	}
+
+	if len(rule.files) > 0 && !matchesAny(rule.files, s.Files) {
+		return false
+	}
+
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAny(patterns []*regexp.Regexp, files []string) bool {
+	for _, file := range files {
+		for _, re := range patterns {
+			if re.MatchString(file) {
+				return true
+			}
+		}
+	}
+	return false
+}
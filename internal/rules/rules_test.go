@@ -0,0 +1,242 @@
+package rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+)
+
+var fixture = heredoc.Doc(`
+	rules:
+	  - labels: [bug]
+	    title: "(?i)crash|panic"
+	  - labels: [needs-triage]
+	    type: issue
+	    author-association: [none, first_time_contributor]
+	  - labels: [documentation]
+	    type: pr
+	    files: ["docs/**", "*.md"]
+	  - labels: [documentation, Bug]
+	    branch: "^docs/"
+	  - labels: ["area: cli"]
+	    files: ["internal/cmd/**/*.go"]
+	    body: "(?m)^- \\[x\\] CLI"
+`)
+
+func TestEvaluate(t *testing.T) {
+	rules, err := Read(strings.NewReader(fixture))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    []string
+	}{
+		{
+			name: "title",
+			subject: Subject{
+				Title:             "Panic when importing",
+				AuthorAssociation: "MEMBER",
+			},
+			want: []string{"bug"},
+		},
+		{
+			name: "first-time issue",
+			subject: Subject{
+				Title:             "Crash on export",
+				AuthorAssociation: "FIRST_TIME_CONTRIBUTOR",
+			},
+			want: []string{"bug", "needs-triage"},
+		},
+		{
+			name: "already applied",
+			subject: Subject{
+				Title:             "Crash on export",
+				AuthorAssociation: "NONE",
+				Labels:            []string{"BUG"},
+			},
+			want: []string{"needs-triage"},
+		},
+		{
+			name: "files ignored for issues",
+			subject: Subject{
+				Title: "Update README.md",
+				Files: []string{"README.md"},
+			},
+		},
+		{
+			name: "pull request files",
+			subject: Subject{
+				Title:             "Fix typo",
+				AuthorAssociation: "NONE",
+				PullRequest:       true,
+				Files:             []string{"internal/github/labels.go", "docs/labels/README.md"},
+			},
+			want: []string{"documentation"},
+		},
+		{
+			name: "pull request branch",
+			subject: Subject{
+				PullRequest: true,
+				Branch:      "docs/readme",
+			},
+			want: []string{"documentation", "Bug"},
+		},
+		{
+			name: "all conditions",
+			subject: Subject{
+				Body:        "- [ ] Docs\n- [x] CLI\n",
+				PullRequest: true,
+				Files:       []string{"internal/cmd/issues/auto.go"},
+			},
+			want: []string{"area: cli"},
+		},
+		{
+			name: "not all conditions",
+			subject: Subject{
+				Body:        "- [x] CLI\n",
+				PullRequest: true,
+				Files:       []string{"internal/github/issues.go"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Evaluate(tt.subject); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		want  int
+		wantE string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "fixture",
+			data: fixture,
+			want: 5,
+		},
+		{
+			name:  "no labels",
+			data:  "rules:\n  - title: crash\n",
+			wantE: "rules[0]: labels are required",
+		},
+		{
+			name:  "no conditions",
+			data:  "rules:\n  - labels: [bug]\n",
+			wantE: "rules[0]: at least one condition is required",
+		},
+		{
+			name:  "invalid regexp",
+			data:  "rules:\n  - labels: [bug]\n    title: \"(crash\"\n",
+			wantE: "rules[0]: title: error parsing regexp: missing closing ): `(crash`",
+		},
+		{
+			name:  "invalid glob",
+			data:  "rules:\n  - labels: [bug]\n    files: [\"[a\"]\n",
+			wantE: `rules[0]: files: invalid pattern "[a"`,
+		},
+		{
+			name:  "invalid type",
+			data:  "rules:\n  - labels: [bug]\n    type: discussion\n",
+			wantE: `rules[0]: type: expected "issue" or "pull-request", got "discussion"`,
+		},
+		{
+			name:  "unknown field",
+			data:  "rules:\n  - labels: [bug]\n    titles: crash\n",
+			wantE: "yaml: unmarshal errors:\n  line 3: field titles not found in type rules.ruleDef",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.data))
+			if err != nil {
+				if err.Error() != tt.wantE {
+					t.Errorf("Read() error = %q, want %q", err, tt.wantE)
+				}
+				return
+			} else if tt.wantE != "" {
+				t.Errorf("Read() expected error %q", tt.wantE)
+				return
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("Read() returned %d rules, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_compileGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		{
+			pattern: "*.md",
+			matches: []string{"README.md", "docs/labels.md"},
+			misses:  []string{"README.mdx", "docs.md/labels.go"},
+		},
+		{
+			pattern: "docs/*.md",
+			matches: []string{"docs/README.md"},
+			misses:  []string{"docs/api/README.md", "src/docs/README.md"},
+		},
+		{
+			pattern: "docs/**",
+			matches: []string{"docs/README.md", "docs/api/README.md"},
+			misses:  []string{"src/docs/README.md"},
+		},
+		{
+			pattern: "**/testdata/*.json",
+			matches: []string{"testdata/labels.json", "internal/github/testdata/labels.json"},
+			misses:  []string{"testdata/nested/labels.json"},
+		},
+		{
+			pattern: "internal/[cg]*/?.go",
+			matches: []string{"internal/cmd/a.go", "internal/github/b.go"},
+			misses:  []string{"internal/labelset/a.go", "internal/cmd/ab.go"},
+		},
+		{
+			pattern: "[^a]*.go",
+			matches: []string{"main.go"},
+			misses:  []string{"apply.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			re, err := compileGlob(tt.pattern)
+			if err != nil {
+				t.Fatalf("compileGlob() error = %v", err)
+			}
+
+			for _, file := range tt.matches {
+				if !re.MatchString(file) {
+					t.Errorf("compileGlob(%q) does not match %q", tt.pattern, file)
+				}
+			}
+
+			for _, file := range tt.misses {
+				if re.MatchString(file) {
+					t.Errorf("compileGlob(%q) matches %q", tt.pattern, file)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Vision is a type of color vision to simulate.
+type Vision string
+
+const (
+	NormalVision Vision = "normal"
+	Protanopia   Vision = "protanopia"
+	Deuteranopia Vision = "deuteranopia"
+	Tritanopia   Vision = "tritanopia"
+)
+
+// Visions returns normal vision followed by simulated color vision deficiencies.
+func Visions() []Vision {
+	return []Vision{NormalVision, Protanopia, Deuteranopia, Tritanopia}
+}
+
+// Machado, Oliveira, and Fernandes (2009) matrices for full severity applied to linear RGB.
+var visionMatrices = map[Vision][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+	Tritanopia: {
+		{1.255528, -0.076749, -0.178779},
+		{-0.078411, 0.930809, 0.147602},
+		{0.004733, 0.691367, 0.303900},
+	},
+}
+
+// TextColor returns the color GitHub renders label text on a background color: "000000" or "FFFFFF".
+func TextColor(color string) (string, error) {
+	c, err := parseHex(color)
+	if err != nil {
+		return "", err
+	}
+
+	// GitHub compares the perceived lightness of the gamma-encoded color to a fixed threshold.
+	if 0.2126*c[0]+0.7152*c[1]+0.0722*c[2] < 0.453 {
+		return "FFFFFF", nil
+	}
+
+	return "000000", nil
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors from 1 to 21.
+func ContrastRatio(a, b string) (float64, error) {
+	ca, err := parseHex(a)
+	if err != nil {
+		return 0, err
+	}
+
+	cb, err := parseHex(b)
+	if err != nil {
+		return 0, err
+	}
+
+	la, lb := luminance(linearize(ca)), luminance(linearize(cb))
+	if la < lb {
+		la, lb = lb, la
+	}
+
+	return (la + 0.05) / (lb + 0.05), nil
+}
+
+// ColorDistance returns the CIE76 color difference (ΔE) between two colors.
+// Differences less than about 2.3 are imperceptible and differences less than 10 are easily confused.
+func ColorDistance(a, b string) (float64, error) {
+	ca, err := parseHex(a)
+	if err != nil {
+		return 0, err
+	}
+
+	cb, err := parseHex(b)
+	if err != nil {
+		return 0, err
+	}
+
+	la, lb := lab(linearize(ca)), lab(linearize(cb))
+	return math.Sqrt(math.Pow(la[0]-lb[0], 2) + math.Pow(la[1]-lb[1], 2) + math.Pow(la[2]-lb[2], 2)), nil
+}
+
+// SimulateVision returns the color as it appears with the given color vision.
+func SimulateVision(color string, vision Vision) (string, error) {
+	c, err := parseHex(color)
+	if err != nil {
+		return "", err
+	}
+
+	if vision == NormalVision {
+		return strings.ToUpper(strings.TrimPrefix(color, "#")), nil
+	}
+
+	m, ok := visionMatrices[vision]
+	if !ok {
+		return "", fmt.Errorf("unsupported vision %q", vision)
+	}
+
+	l := linearize(c)
+	var s [3]float64
+	for i := range s {
+		s[i] = m[i][0]*l[0] + m[i][1]*l[1] + m[i][2]*l[2]
+	}
+
+	return fmt.Sprintf("%02X%02X%02X", toByte(delinearize(s[0])), toByte(delinearize(s[1])), toByte(delinearize(s[2]))), nil
+}
+
+func parseHex(color string) ([3]float64, error) {
+	color = strings.TrimPrefix(color, "#")
+	if !hexColorRegexp.MatchString(color) {
+		return [3]float64{}, fmt.Errorf("expected 6 hexadecimal digits, got %q", color)
+	}
+
+	var c [3]float64
+	for i := range c {
+		n, _ := strconv.ParseUint(color[2*i:2*i+2], 16, 8)
+		c[i] = float64(n) / 255
+	}
+
+	return c, nil
+}
+
+func linearize(c [3]float64) [3]float64 {
+	var l [3]float64
+	for i, v := range c {
+		if v <= 0.04045 {
+			l[i] = v / 12.92
+		} else {
+			l[i] = math.Pow((v+0.055)/1.055, 2.4)
+		}
+	}
+	return l
+}
+
+func delinearize(v float64) float64 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+func luminance(l [3]float64) float64 {
+	return 0.2126*l[0] + 0.7152*l[1] + 0.0722*l[2]
+}
+
+func lab(l [3]float64) [3]float64 {
+	// Convert to CIE XYZ relative to the D65 white point.
+	x := (0.4124564*l[0] + 0.3575761*l[1] + 0.1804375*l[2]) / 0.95047
+	y := 0.2126729*l[0] + 0.7151522*l[1] + 0.0721750*l[2]
+	z := (0.0193339*l[0] + 0.1191920*l[1] + 0.9503041*l[2]) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+
+	fx, fy, fz := f(x), f(y), f(z)
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
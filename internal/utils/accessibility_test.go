@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTextColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  string
+		wantE bool
+	}{
+		{color: "000000", want: "FFFFFF"},
+		{color: "ffffff", want: "000000"},
+		{color: "#d73a4a", want: "FFFFFF"},
+		{color: "a2eeef", want: "000000"},
+		{color: "0075ca", want: "FFFFFF"},
+		{color: "red", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			if got, err := TextColor(tt.color); (err != nil) != tt.wantE {
+				t.Errorf("TextColor() error = %v, wantE %v", err, tt.wantE)
+			} else if got != tt.want {
+				t.Errorf("TextColor() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		want  float64
+		wantE bool
+	}{
+		{a: "000000", b: "FFFFFF", want: 21},
+		{a: "FFFFFF", b: "000000", want: 21},
+		{a: "777777", b: "777777", want: 1},
+		{a: "777777", b: "FFFFFF", want: 4.48},
+		{a: "zzzzzz", b: "FFFFFF", wantE: true},
+		{a: "FFFFFF", b: "zzzzzz", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got, err := ContrastRatio(tt.a, tt.b); (err != nil) != tt.wantE {
+				t.Errorf("ContrastRatio() error = %v, wantE %v", err, tt.wantE)
+			} else if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ContrastRatio() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		want  float64
+		wantE bool
+	}{
+		{a: "000000", b: "000000", want: 0},
+		{a: "000000", b: "FFFFFF", want: 100},
+		{a: "FF0000", b: "FE0000", want: 0.37},
+		{a: "zzzzzz", b: "FFFFFF", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got, err := ColorDistance(tt.a, tt.b); (err != nil) != tt.wantE {
+				t.Errorf("ColorDistance() error = %v, wantE %v", err, tt.wantE)
+			} else if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("ColorDistance() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimulateVision(t *testing.T) {
+	tests := []struct {
+		color  string
+		vision Vision
+		want   string
+		wantE  bool
+	}{
+		{color: "#d73a4a", vision: NormalVision, want: "D73A4A"},
+		{color: "000000", vision: Protanopia, want: "000000"},
+		{color: "FFFFFF", vision: Deuteranopia, want: "FFFFFF"},
+		{color: "FF0000", vision: Protanopia, want: "6D5F00"},
+		{color: "00FF00", vision: Deuteranopia, want: "EFD63A"},
+		{color: "0000FF", vision: Tritanopia, want: "006B96"},
+		{color: "FF0000", vision: "unknown", wantE: true},
+		{color: "red", vision: NormalVision, wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.vision)+"-"+tt.color, func(t *testing.T) {
+			if got, err := SimulateVision(tt.color, tt.vision); (err != nil) != tt.wantE {
+				t.Errorf("SimulateVision() error = %v, wantE %v", err, tt.wantE)
+			} else if got != tt.want {
+				t.Errorf("SimulateVision() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,20 @@ package utils
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
+var (
+	hexColorRegexp       = regexp.MustCompile("^#?[A-Fa-f0-9]{6}$")
+	shortHexColorRegexp  = regexp.MustCompile("^#[A-Fa-f0-9]{3}$")
+	colorFunctionRegexp  = regexp.MustCompile(`^(rgb|hsl)(a?)\((.*)\)$`)
+	colorArgumentsRegexp = regexp.MustCompile(`\s*,\s*|\s+`)
+)
+
 func RandomColor() string {
 	r := rand.Int31n(256)
 	g := rand.Int31n(256)
@@ -15,14 +24,92 @@ func RandomColor() string {
 	return fmt.Sprintf("%02X%02X%02X", r, g, b)
 }
 
+// ValidateColor resolves s to 6 hexadecimal digits without a "#" prefix.
+// Colors may be specified as 6 hexadecimal digits with optional "#" prefix, 3 hexadecimal digits with "#" prefix,
+// CSS color names like "tomato", or CSS functions "rgb(r, g, b)" and "hsl(h, s%, l%)".
+// Label colors are opaque, so "rgba()" and "hsla()" are accepted only without an alpha value.
 func ValidateColor(s string) (string, error) {
-	if match, _ := regexp.MatchString("^#?[A-Fa-f0-9]{6}$", s); !match {
-		return "", fmt.Errorf(`colors must include 6 hexadecimal digits for RGB with optional "#" prefix`)
+	if hexColorRegexp.MatchString(s) {
+		return strings.TrimPrefix(s, "#"), nil
+	}
+
+	if shortHexColorRegexp.MatchString(s) {
+		return string([]byte{s[1], s[1], s[2], s[2], s[3], s[3]}), nil
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(s))
+	if color, ok := colorNames[strings.ReplaceAll(normalized, " ", "")]; ok {
+		return color, nil
+	}
+
+	if matches := colorFunctionRegexp.FindStringSubmatch(normalized); matches != nil {
+		name := matches[1] + matches[2]
+		args := colorArgumentsRegexp.Split(strings.TrimSpace(matches[3]), -1)
+		if len(args) == 4 || strings.Contains(matches[3], "/") {
+			return "", fmt.Errorf("%s() alpha is not supported because label colors are opaque", name)
+		}
+		if len(args) != 3 {
+			return "", fmt.Errorf("%s() requires 3 arguments, got %d", name, len(args))
+		}
+
+		if matches[1] == "rgb" {
+			return parseRGB(args)
+		}
+		return parseHSL(args)
+	}
+
+	return "", fmt.Errorf(`colors must include 6 hexadecimal digits for RGB with optional "#" prefix, 3 hexadecimal digits with "#" prefix, a color name, rgb(), or hsl()`)
+}
+
+func parseRGB(args []string) (string, error) {
+	var rgb [3]int
+	for i, arg := range args {
+		if strings.HasSuffix(arg, "%") {
+			f, err := parsePercentage(arg)
+			if err != nil {
+				return "", err
+			}
+			rgb[i] = toByte(f)
+			continue
+		}
+
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 0 || n > 255 {
+			return "", fmt.Errorf("rgb() arguments must be integers between 0 and 255 or percentages, got %q", arg)
+		}
+		rgb[i] = n
+	}
+
+	return fmt.Sprintf("%02X%02X%02X", rgb[0], rgb[1], rgb[2]), nil
+}
+
+func parseHSL(args []string) (string, error) {
+	h, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+	if err != nil || math.IsNaN(h) || math.IsInf(h, 0) {
+		return "", fmt.Errorf("hsl() hue must be a number of degrees, got %q", args[0])
 	}
 
-	if strings.HasPrefix(s, "#") {
-		return s[1:], nil
+	s, err := parsePercentage(args[1])
+	if err != nil {
+		return "", err
+	}
+
+	l, err := parsePercentage(args[2])
+	if err != nil {
+		return "", err
+	}
+
+	// Match the case of rgb() colors.
+	return strings.ToUpper(HSLToHex(h, s, l)), nil
+}
+
+func parsePercentage(s string) (float64, error) {
+	if strings.HasSuffix(s, "%") {
+		// NaN fails both comparisons.
+		if f, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64); err == nil && f >= 0 && f <= 100 {
+			return f / 100, nil
+		}
 	}
 
-	return s, nil
+	return 0, fmt.Errorf("expected percentage between 0%% and 100%%, got %q", s)
 }
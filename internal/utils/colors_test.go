@@ -17,7 +17,7 @@ func Test_RandomColor(t *testing.T) {
 	})
 }
 
-// cSpell:ignore aabbzz
+// cSpell:ignore aabbzz notacolor
 func Test_ValidateColor(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -50,6 +50,101 @@ func Test_ValidateColor(t *testing.T) {
 			color: "aabbzz",
 			wantE: true,
 		},
+		{
+			name:  "short color with # prefix",
+			color: "#f0A",
+			want:  "ff00AA",
+		},
+		{
+			name:  "color name",
+			color: "tomato",
+			want:  "FF6347",
+		},
+		{
+			name:  "color name with mixed case and spaces",
+			color: " Rebecca Purple ",
+			want:  "663399",
+		},
+		{
+			name:  "unknown color name",
+			color: "notacolor",
+			wantE: true,
+		},
+		{
+			name:  "rgb",
+			color: "rgb(255, 99, 71)",
+			want:  "FF6347",
+		},
+		{
+			name:  "rgb with spaces",
+			color: "RGB(255 99 71)",
+			want:  "FF6347",
+		},
+		{
+			name:  "rgb with percentages",
+			color: "rgb(100%, 0%, 50%)",
+			want:  "FF0080",
+		},
+		{
+			name:  "rgb out of range",
+			color: "rgb(256, 0, 0)",
+			wantE: true,
+		},
+		{
+			name:  "rgb too few arguments",
+			color: "rgb(255, 0)",
+			wantE: true,
+		},
+		{
+			name:  "hsl",
+			color: "hsl(120, 100%, 50%)",
+			want:  "00FF00",
+		},
+		{
+			name:  "hsl with degrees",
+			color: "hsl(240deg 100% 50%)",
+			want:  "0000FF",
+		},
+		{
+			name:  "hsl without percentages",
+			color: "hsl(240, 100, 50)",
+			wantE: true,
+		},
+		{
+			name:  "hsl nan hue",
+			color: "hsl(nan, 50%, 50%)",
+			wantE: true,
+		},
+		{
+			name:  "hsl infinite hue",
+			color: "hsl(-inf, 50%, 50%)",
+			wantE: true,
+		},
+		{
+			name:  "hsl nan saturation",
+			color: "hsl(120, nan%, 50%)",
+			wantE: true,
+		},
+		{
+			name:  "rgb infinite percentage",
+			color: "rgb(inf%, 0%, 0%)",
+			wantE: true,
+		},
+		{
+			name:  "rgba without alpha",
+			color: "rgba(255, 99, 71)",
+			want:  "FF6347",
+		},
+		{
+			name:  "rgba with alpha",
+			color: "rgba(255, 99, 71, 0.5)",
+			wantE: true,
+		},
+		{
+			name:  "hsl with slash alpha",
+			color: "hsl(120 100% 50% / 50%)",
+			wantE: true,
+		},
 	}
 
 	for _, tt := range tests {
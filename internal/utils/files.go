@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// OpenFile opens a file named on the command line from fsys, which should contain the working directory.
+// Names like "./labels.csv" are cleaned, and absolute names or names outside fsys are opened from the OS.
+func OpenFile(fsys fs.FS, name string) (fs.File, error) {
+	fsys, name = FileFS(fsys, name)
+	return fsys.Open(name)
+}
+
+// FileFS returns a file system containing a file named on the command line, and the name of the file within it,
+// so that files relative to it can also be opened. Like OpenFile, absolute names or names outside fsys
+// are resolved against the root of the OS file system.
+func FileFS(fsys fs.FS, name string) (fs.FS, string) {
+	cleaned := path.Clean(filepath.ToSlash(name))
+	if !filepath.IsAbs(name) && fs.ValidPath(cleaned) {
+		return fsys, cleaned
+	}
+
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		// Let fsys report the invalid name.
+		return fsys, cleaned
+	}
+
+	root := filepath.VolumeName(abs) + string(filepath.Separator)
+	return os.DirFS(root), filepath.ToSlash(strings.TrimPrefix(abs, root))
+}
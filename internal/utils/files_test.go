@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOpenFile(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "labels.csv")
+	if err := os.WriteFile(abs, []byte("absolute"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{
+		"labels.csv":         &fstest.MapFile{Data: []byte("relative")},
+		".github/labels.yml": &fstest.MapFile{Data: []byte("nested")},
+	}
+
+	tests := []struct {
+		name  string
+		want  string
+		wantE bool
+	}{
+		{name: "labels.csv", want: "relative"},
+		{name: "./labels.csv", want: "relative"},
+		{name: ".github/../.github/labels.yml", want: "nested"},
+		{name: abs, want: "absolute"},
+		{name: "missing.csv", wantE: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := OpenFile(fsys, tt.name)
+			if (err != nil) != tt.wantE {
+				t.Fatalf("OpenFile() error = %v, wantE %v", err, tt.wantE)
+			}
+			if tt.wantE {
+				return
+			}
+			defer file.Close()
+
+			got, err := io.ReadAll(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("OpenFile() read %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileFS(t *testing.T) {
+	abs := filepath.Join(t.TempDir(), "labels.yml")
+	if err := os.WriteFile(abs, []byte("labels: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		t.Skipf("cannot make %q relative: %v", abs, err)
+	}
+
+	for _, name := range []string{abs, rel} {
+		t.Run(name, func(t *testing.T) {
+			fsys, got := FileFS(fstest.MapFS{}, name)
+			if !fs.ValidPath(got) {
+				t.Fatalf("FileFS() name = %q, expected a valid path", got)
+			}
+
+			// Files next to the named file can be opened too.
+			if _, err := fs.Stat(fsys, path.Join(path.Dir(got), "labels.yml")); err != nil {
+				t.Errorf("Stat() error = %v", err)
+			}
+		})
+	}
+}
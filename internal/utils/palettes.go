@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SpectrumPalette is the name of the palette that generates evenly spaced hues instead of choosing from a fixed list.
+const SpectrumPalette = "spectrum"
+
+var palettes = map[string][]string{
+	// Colors GitHub assigns to labels in new repositories.
+	"github": {
+		"d73a4a",
+		"0075ca",
+		"cfd3d7",
+		"a2eeef",
+		"7057ff",
+		"008672",
+		"e4e669",
+		"d876e3",
+		"ffffff",
+	},
+	// Okabe-Ito palette distinguishable with common color vision deficiencies.
+	"okabe-ito": {
+		"e69f00",
+		"56b4e9",
+		"009e73",
+		"f0e442",
+		"0072b2",
+		"d55e00",
+		"cc79a7",
+		"000000",
+	},
+	// Tailwind CSS 500 shades.
+	"tailwind": {
+		"ef4444",
+		"f97316",
+		"f59e0b",
+		"eab308",
+		"84cc16",
+		"22c55e",
+		"10b981",
+		"14b8a6",
+		"06b6d4",
+		"0ea5e9",
+		"3b82f6",
+		"6366f1",
+		"8b5cf6",
+		"a855f7",
+		"d946ef",
+		"ec4899",
+		"f43f5e",
+		"64748b",
+	},
+}
+
+// Saturation and lightness used for generated colors, which are readable with either black or white text.
+const (
+	spectrumSaturation = 0.65
+	spectrumLightness  = 0.55
+)
+
+// PaletteNames returns the sorted names of supported palettes.
+func PaletteNames() []string {
+	names := make([]string, 0, len(palettes)+1)
+	for name := range palettes {
+		names = append(names, name)
+	}
+	names = append(names, SpectrumPalette)
+	sort.Strings(names)
+
+	return names
+}
+
+// ValidatePalette returns an error if name is not a supported palette.
+func ValidatePalette(name string) error {
+	if name == SpectrumPalette {
+		return nil
+	}
+
+	if _, ok := palettes[name]; !ok {
+		return fmt.Errorf("unsupported palette %q, expected %v", name, PaletteNames())
+	}
+
+	return nil
+}
+
+// PaletteColors returns n colors from the palette name.
+// Colors from fixed palettes repeat in order when n exceeds the number of colors in the palette.
+// The spectrum palette returns n colors with hues evenly spaced around the color wheel.
+func PaletteColors(name string, n int) ([]string, error) {
+	if err := ValidatePalette(name); err != nil {
+		return nil, err
+	}
+
+	colors := make([]string, n)
+	if name == SpectrumPalette {
+		for i := range colors {
+			colors[i] = HSLToHex(360*float64(i)/float64(n), spectrumSaturation, spectrumLightness)
+		}
+		return colors, nil
+	}
+
+	palette := palettes[name]
+	for i := range colors {
+		colors[i] = palette[i%len(palette)]
+	}
+
+	return colors, nil
+}
+
+// RandomPaletteColor returns a random color from the palette name.
+func RandomPaletteColor(name string) (string, error) {
+	if err := ValidatePalette(name); err != nil {
+		return "", err
+	}
+
+	if name == SpectrumPalette {
+		return HSLToHex(float64(rand.Int31n(360)), spectrumSaturation, spectrumLightness), nil
+	}
+
+	palette := palettes[name]
+	return palette[rand.Intn(len(palette))], nil
+}
+
+// HSLToHex converts hue in degrees, and saturation and lightness between 0 and 1 to 6 lowercase hexadecimal digits like fixed palette colors.
+func HSLToHex(h, s, l float64) string {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := l - c/2
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+
+	return fmt.Sprintf("%02x%02x%02x", toByte(r+m), toByte(g+m), toByte(b+m))
+}
+
+func toByte(f float64) int {
+	return int(math.Round(math.Max(0, math.Min(1, f)) * 255))
+}
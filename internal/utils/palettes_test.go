@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestPaletteNames(t *testing.T) {
+	got := PaletteNames()
+	want := []string{"github", "okabe-ito", "spectrum", "tailwind"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PaletteNames() = %v, want %v", got, want)
+	}
+}
+
+func TestPaletteColors(t *testing.T) {
+	tests := []struct {
+		name    string
+		palette string
+		n       int
+		want    []string
+		wantE   bool
+	}{
+		{
+			name:    "fixed palette",
+			palette: "github",
+			n:       2,
+			want:    []string{"d73a4a", "0075ca"},
+		},
+		{
+			name:    "fixed palette repeats",
+			palette: "okabe-ito",
+			n:       10,
+			want:    []string{"e69f00", "56b4e9", "009e73", "f0e442", "0072b2", "d55e00", "cc79a7", "000000", "e69f00", "56b4e9"},
+		},
+		{
+			name:    "spectrum",
+			palette: "spectrum",
+			n:       3,
+			want:    []string{"d74242", "42d742", "4242d7"},
+		},
+		{
+			name:    "unknown palette",
+			palette: "unknown",
+			n:       1,
+			wantE:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := PaletteColors(tt.palette, tt.n); (err != nil) != tt.wantE {
+				t.Errorf("PaletteColors() error = %v, wantE %v", err, tt.wantE)
+			} else if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PaletteColors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRandomPaletteColor(t *testing.T) {
+	re := regexp.MustCompile("^[A-Fa-f0-9]{6}$")
+	for _, name := range PaletteNames() {
+		t.Run(name, func(t *testing.T) {
+			if got, err := RandomPaletteColor(name); err != nil {
+				t.Errorf("RandomPaletteColor() error = %v", err)
+			} else if !re.MatchString(got) {
+				t.Errorf("RandomPaletteColor() = %s, want pattern: %s", got, re.String())
+			}
+		})
+	}
+
+	if _, err := RandomPaletteColor("unknown"); err == nil {
+		t.Error("RandomPaletteColor() expected error")
+	}
+}
+
+func TestHSLToHex(t *testing.T) {
+	tests := []struct {
+		h, s, l float64
+		want    string
+	}{
+		{0, 1, 0.5, "ff0000"},
+		{120, 1, 0.5, "00ff00"},
+		{240, 1, 0.5, "0000ff"},
+		{360, 1, 0.5, "ff0000"},
+		{-120, 1, 0.5, "0000ff"},
+		{0, 0, 0, "000000"},
+		{0, 0, 1, "ffffff"},
+		{0, 0, 0.5, "808080"},
+	}
+
+	for _, tt := range tests {
+		if got := HSLToHex(tt.h, tt.s, tt.l); got != tt.want {
+			t.Errorf("HSLToHex(%v, %v, %v) = %s, want %s", tt.h, tt.s, tt.l, got, tt.want)
+		}
+	}
+}
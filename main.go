@@ -3,12 +3,21 @@ package main
 import (
 	"os"
 
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/heaths/gh-label/internal/cmd/analyze"
+	"github.com/heaths/gh-label/internal/cmd/browse"
+	"github.com/heaths/gh-label/internal/cmd/config"
 	"github.com/heaths/gh-label/internal/cmd/create"
 	"github.com/heaths/gh-label/internal/cmd/delete"
 	"github.com/heaths/gh-label/internal/cmd/edit"
 	"github.com/heaths/gh-label/internal/cmd/export"
+	"github.com/heaths/gh-label/internal/cmd/history"
 	importcmd "github.com/heaths/gh-label/internal/cmd/import"
+	"github.com/heaths/gh-label/internal/cmd/issues"
+	"github.com/heaths/gh-label/internal/cmd/lint"
 	"github.com/heaths/gh-label/internal/cmd/list"
+	"github.com/heaths/gh-label/internal/cmd/stats"
+	"github.com/heaths/gh-label/internal/cmd/validate"
 	"github.com/heaths/gh-label/internal/options"
 	"github.com/spf13/cobra"
 )
@@ -23,12 +32,38 @@ func main() {
 
 	opts := options.New(&rootCmd)
 
+	rootCmd.AddCommand(issues.AddCmd(opts))
+	rootCmd.AddCommand(analyze.AnalyzeCmd(opts))
+	rootCmd.AddCommand(issues.ApplyCmd(opts))
+	rootCmd.AddCommand(issues.AutoCmd(opts))
+	browseCmd := browse.BrowseCmd(opts)
+	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(config.ConfigCmd(opts))
 	rootCmd.AddCommand(create.CreateCmd(opts))
 	rootCmd.AddCommand(delete.DeleteCmd(opts))
 	rootCmd.AddCommand(edit.EditCmd(opts))
 	rootCmd.AddCommand(export.ExportCmd(opts))
+	rootCmd.AddCommand(history.HistoryCmd(opts))
 	rootCmd.AddCommand(importcmd.ImportCmd(opts))
+	rootCmd.AddCommand(lint.LintCmd(opts))
 	rootCmd.AddCommand(list.ListCmd(opts))
+	rootCmd.AddCommand(issues.RemoveCmd(opts))
+	rootCmd.AddCommand(stats.StatsCmd(opts))
+	rootCmd.AddCommand(validate.ValidateCmd())
+
+	// Browse labels if run without a command in a terminal.
+	rootCmd.Annotations = map[string]string{
+		options.OfflineAnnotation: "true",
+	}
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if iostreams.System().CanPrompt() {
+			if err := opts.ResolveRepo(); err != nil {
+				return err
+			}
+			return browseCmd.RunE(browseCmd, args)
+		}
+		return cmd.Help()
+	}
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)